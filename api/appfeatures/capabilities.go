@@ -29,6 +29,24 @@ var FeatureMap = map[Features]string{
 	FeatureMediaPlayer: "Media Player",
 }
 
+// OptionalFeatures describes optional functionality within a feature. An optional feature
+// may be missing even if the feature it belongs to is supported, in which case only the
+// functionality that is described by the optional feature is unavailable.
+type OptionalFeatures uint
+
+// The different kinds of individual optional features.
+const (
+	OptionalFeatureNone              OptionalFeatures = 0 // The zero value for this type.
+	OptionalFeaturePairingCapability                  = 1 << iota
+	OptionalFeatureProfileConnection
+)
+
+// OptionalFeatureMap holds the feature that each optional feature belongs to.
+var OptionalFeatureMap = map[OptionalFeatures]Features{
+	OptionalFeaturePairingCapability: FeaturePairing,
+	OptionalFeatureProfileConnection: FeatureConnection,
+}
+
 // Add adds the provided features to the existing features.
 func (c *Features) Add(features ...Features) {
 	for _, f := range features {
@@ -43,6 +61,19 @@ func (c *Features) Remove(features ...Features) {
 	}
 }
 
+// Known returns only the known features (features which are present within [FeatureMap])
+// from the existing features. Any unknown features, for example features that are advertised
+// by a newer version of a Bluetooth daemon or service, are ignored.
+func (c *Features) Known() Features {
+	var known Features
+
+	for feature := range FeatureMap {
+		known |= feature
+	}
+
+	return *c & known
+}
+
 // AbsentFeatures returns a list of features that are not present in the existing features.
 func (c *Features) AbsentFeatures() []Features {
 	s := make([]Features, 0, len(FeatureMap))
@@ -83,9 +114,36 @@ func (c *Features) Slice() []Features {
 	return s
 }
 
-// FeatureSet holds all supported features and feature related errors.
+// FeatureState describes the availability of a feature.
+type FeatureState int
+
+// The different feature availability states.
+const (
+	FeatureStateAbsent    FeatureState = iota // The feature is not available.
+	FeatureStateDegraded                      // The feature is partially available.
+	FeatureStateAvailable                     // The feature is fully available.
+)
+
+// String returns the string representation of the feature state.
+func (s FeatureState) String() string {
+	switch s {
+	case FeatureStateDegraded:
+		return "degraded"
+
+	case FeatureStateAvailable:
+		return "available"
+	}
+
+	return "absent"
+}
+
+// FeatureSet holds all supported features, degraded features, missing optional features
+// and feature related errors. A degraded feature is also a supported feature, but with limited
+// functionality. A feature with a missing optional feature is still fully available.
 type FeatureSet struct {
 	Supported Features
+	Missing   OptionalFeatures
+	Degraded  Degradations
 	Errors    Errors
 }
 
@@ -108,6 +166,42 @@ func MergedFeatureSet() *FeatureSet {
 	return &FeatureSet{Supported: features}
 }
 
+// Degrade marks the provided feature as degraded (partially available), with the reason
+// for the degradation. The feature is also added to the supported features.
+func (c *FeatureSet) Degrade(feature Features, reason string) {
+	c.Supported.Add(feature)
+	c.Degraded.Append(NewDegradation(feature, reason))
+}
+
+// SetMissing marks the provided optional features as missing. The features which the
+// optional features belong to are not affected.
+func (c *FeatureSet) SetMissing(optional ...OptionalFeatures) {
+	for _, o := range optional {
+		c.Missing |= o
+	}
+}
+
+// HasOptional returns whether the provided optional feature is available, that is,
+// the feature it belongs to is supported, and it is not marked as missing.
+func (c *FeatureSet) HasOptional(optional OptionalFeatures) bool {
+	feature, ok := OptionalFeatureMap[optional]
+
+	return ok && c.Supported&feature != 0 && c.Missing&optional == 0
+}
+
+// State returns the availability state of the provided feature.
+func (c *FeatureSet) State(feature Features) FeatureState {
+	switch {
+	case c.Supported&feature == 0:
+		return FeatureStateAbsent
+
+	case c.Degraded.Has(feature):
+		return FeatureStateDegraded
+	}
+
+	return FeatureStateAvailable
+}
+
 // HasAny returns if the feature feature sets contains any of the provided features.
 func (c *FeatureSet) HasAny(compare ...Features) bool {
 	var compared int
@@ -138,6 +232,55 @@ func (c *FeatureSet) Has(compare ...Features) bool {
 	return compared > 0 && compared == len(compare)
 }
 
+// Degradation describes a feature which is only partially available,
+// and the reason for its limited functionality.
+type Degradation struct {
+	Feature Features
+	Reason  string
+}
+
+// Degradations holds a list of degraded features.
+type Degradations struct {
+	degraded map[Features]Degradation
+}
+
+// NewDegradation returns a feature-based Degradation.
+func NewDegradation(c Features, reason string) *Degradation {
+	return &Degradation{
+		Feature: c,
+		Reason:  reason,
+	}
+}
+
+// Append appends a single degraded feature to the degraded feature list.
+func (c *Degradations) Append(d *Degradation) {
+	if c.degraded == nil {
+		c.degraded = make(map[Features]Degradation)
+	}
+
+	c.degraded[d.Feature] = *d
+}
+
+// Has returns whether the provided feature is degraded.
+func (c *Degradations) Has(feature Features) bool {
+	_, ok := c.degraded[feature]
+
+	return ok
+}
+
+// Exists checks and returns all degraded features.
+func (c *Degradations) Exists() (map[Features]Degradation, bool) {
+	return c.degraded, c.degraded != nil
+}
+
+// String returns a text representation of the degraded feature.
+func (c *Degradation) String() string {
+	return fmt.Sprintf(
+		"Capabilities '%s' are partially available: %s",
+		c.Feature.String(), c.Reason,
+	)
+}
+
 // Error describes an error which occurred while attempting
 // to enable support for the specified feature.
 type Error struct {
@@ -0,0 +1,95 @@
+package appfeatures
+
+import "testing"
+
+func TestFeatureSetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		features Features
+		degrade  Features
+		feature  Features
+		want     FeatureState
+	}{
+		{"absent", FeatureConnection, FeatureNone, FeatureMediaPlayer, FeatureStateAbsent},
+		{"available", FeatureConnection | FeatureMediaPlayer, FeatureNone, FeatureMediaPlayer, FeatureStateAvailable},
+		{"degraded", FeatureConnection | FeatureMediaPlayer, FeatureMediaPlayer, FeatureMediaPlayer, FeatureStateDegraded},
+		{"degraded adds support", FeatureConnection, FeatureMediaPlayer, FeatureMediaPlayer, FeatureStateDegraded},
+		{"other feature degraded", FeatureConnection | FeatureMediaPlayer, FeatureConnection, FeatureMediaPlayer, FeatureStateAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := NewFeatureSet(tt.features, Errors{})
+			if tt.degrade != FeatureNone {
+				set.Degrade(tt.degrade, "reason")
+			}
+
+			if got := set.State(tt.feature); got != tt.want {
+				t.Errorf("State() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeatureSetOptional(t *testing.T) {
+	tests := []struct {
+		name     string
+		features Features
+		missing  []OptionalFeatures
+		optional OptionalFeatures
+		want     bool
+	}{
+		{"available", FeaturePairing, nil, OptionalFeaturePairingCapability, true},
+		{"feature absent", FeatureConnection, nil, OptionalFeaturePairingCapability, false},
+		{"missing", FeaturePairing, []OptionalFeatures{OptionalFeaturePairingCapability}, OptionalFeaturePairingCapability, false},
+		{"other missing", FeatureConnection | FeaturePairing, []OptionalFeatures{OptionalFeaturePairingCapability}, OptionalFeatureProfileConnection, true},
+		{"unknown", FeatureConnection | FeaturePairing, nil, OptionalFeatureNone, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := NewFeatureSet(tt.features, Errors{})
+			set.SetMissing(tt.missing...)
+
+			if got := set.HasOptional(tt.optional); got != tt.want {
+				t.Errorf("HasOptional() = %v, want %v", got, tt.want)
+			}
+
+			// A missing optional feature never degrades the feature it belongs to.
+			for _, feature := range tt.features.Slice() {
+				if got := set.State(feature); got != FeatureStateAvailable {
+					t.Errorf("State(%s) = %s, want %s", feature.String(), got, FeatureStateAvailable)
+				}
+			}
+		})
+	}
+}
+
+func TestFeaturesKnown(t *testing.T) {
+	const unknown Features = 1 << 30
+
+	tests := []struct {
+		name     string
+		features Features
+		want     Features
+	}{
+		{"none", FeatureNone, FeatureNone},
+		{"known", FeatureConnection | FeatureSendFile, FeatureConnection | FeatureSendFile},
+		{"unknown only", unknown, FeatureNone},
+		{"known and unknown", FeaturePairing | unknown, FeaturePairing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.features.Known(); got != tt.want {
+				t.Errorf("Known() = %b, want %b", got, tt.want)
+			}
+
+			// Unknown features are never reported as absent or present.
+			known := tt.features.Known()
+			if got := len(known.AbsentFeatures()) + len(known.Slice()); got != len(FeatureMap) {
+				t.Errorf("%d features are absent or present, want %d", got, len(FeatureMap))
+			}
+		})
+	}
+}
@@ -30,6 +30,65 @@ type Adapter interface {
 
 	// Devices returns all the devices associated with the adapter
 	Devices() ([]DeviceData, error)
+
+	// RemoveAllDevices removes (unpairs and forgets) all the devices associated with the adapter.
+	// If a device cannot be removed, the remaining devices are still removed, and all the
+	// errors that occurred are returned together.
+	RemoveAllDevices() error
+
+	// SetProperty sets a raw property of the adapter.
+	// This is an unstable API, which is meant to access properties that are not yet exposed
+	// by the typed API. The property name and value types are specific to the backend.
+	// Currently is valid only on Linux.
+	SetProperty(name string, value any) error
+
+	// GetProperty gets a raw property of the adapter.
+	// This is an unstable API, which is meant to access properties that are not yet exposed
+	// by the typed API. The property name and value types are specific to the backend.
+	// Currently is valid only on Linux.
+	GetProperty(name string) (any, error)
+}
+
+// AdapterPowerState describes the power state of an adapter.
+type AdapterPowerState string
+
+// The different adapter power states.
+const (
+	PowerStateUnknown     AdapterPowerState = "unknown"
+	PowerStateOff         AdapterPowerState = "off"
+	PowerStateOn          AdapterPowerState = "on"
+	PowerStatePoweringOn  AdapterPowerState = "powering-on"
+	PowerStatePoweringOff AdapterPowerState = "powering-off"
+)
+
+// PowerStateFromPowered returns the power state of an adapter from its powered status.
+// If the powered status is not known, [PowerStateUnknown] is returned.
+func PowerStateFromPowered(powered optional.Optional[bool]) AdapterPowerState {
+	enabled, ok := powered.Get()
+	switch {
+	case !ok:
+		return PowerStateUnknown
+
+	case enabled:
+		return PowerStateOn
+	}
+
+	return PowerStateOff
+}
+
+// PowerStateTransition returns the intermediate power state of an adapter,
+// while the adapter is being powered on (enable) or off.
+func PowerStateTransition(enable bool) AdapterPowerState {
+	if enable {
+		return PowerStatePoweringOn
+	}
+
+	return PowerStatePoweringOff
+}
+
+// String returns the string representation of the power state.
+func (p AdapterPowerState) String() string {
+	return string(p)
 }
 
 // AdapterAddress represents an adapter address.
@@ -73,6 +132,9 @@ type AdapterEventData struct {
 	// for other systems.
 	Alias optional.Optional[string] `json:"alias,omitzero" codec:"Alias,omitempty" doc:"The optional or user-assigned name for the adapter. Usually valid for Linux systems, may be empty or equate to **name** for other systems."`
 
+	// Class holds the class of device of the adapter.
+	Class optional.Optional[uint32] `json:"class,omitzero" codec:"Class,omitempty" doc:"The class of device of the adapter."`
+
 	// Discoverable indicates whether the adapter is discoverable by other devices.
 	Discoverable optional.Optional[bool] `json:"discoverable,omitzero" codec:"Discoverable,omitempty" doc:"Indicates whether the adapter is discoverable by other devices."`
 
@@ -82,6 +144,10 @@ type AdapterEventData struct {
 	// Powered indicates whether the adapter is powered on or off.
 	Powered optional.Optional[bool] `json:"powered,omitzero" codec:"Powered,omitempty" doc:"Indicates whether the adapter is powered on or off."`
 
+	// PowerState indicates the power state of the adapter, including whether the adapter
+	// is transitioning between the powered on and off states.
+	PowerState AdapterPowerState `json:"power_state,omitempty" codec:"-" enum:"unknown,off,on,powering-on,powering-off" doc:"Indicates the power state of the adapter, including whether the adapter is transitioning between the powered on and off states."`
+
 	// Discovering indicates whether the adapter is discovering devices.
 	Discovering optional.Optional[bool] `json:"discovering,omitzero" codec:"Discovering,omitempty" doc:"Indicates whether the adapter is discovering devices."`
 
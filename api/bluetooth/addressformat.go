@@ -0,0 +1,55 @@
+package bluetooth
+
+// AddressStyle describes a style to display a Bluetooth address in.
+type AddressStyle int
+
+// The different address styles.
+const (
+	AddressStyleColon       AddressStyle = iota // 11:22:33:AA:BB:CC, which is the canonical style.
+	AddressStyleColonLower                      // 11:22:33:aa:bb:cc
+	AddressStyleHyphen                          // 11-22-33-AA-BB-CC
+	AddressStyleHyphenLower                     // 11-22-33-aa-bb-cc
+	AddressStyleDotted                          // 1122.33AA.BBCC
+	AddressStyleDottedLower                     // 1122.33aa.bbcc
+	AddressStylePlain                           // 112233AABBCC
+	AddressStylePlainLower                      // 112233aabbcc
+)
+
+// lowercase returns whether the style uses lowercase hexadecimal digits.
+func (a AddressStyle) lowercase() bool {
+	switch a {
+	case AddressStyleColonLower, AddressStyleHyphenLower, AddressStyleDottedLower, AddressStylePlainLower:
+		return true
+	}
+
+	return false
+}
+
+// VendorLookupFunc describes a function to look up the name of a device's vendor, using the
+// organizationally unique identifier (OUI) of the device's address. If the vendor is not known,
+// false is returned.
+type VendorLookupFunc func(oui [3]byte) (vendor string, ok bool)
+
+// AddressFormatter describes how Bluetooth addresses are displayed within an application.
+type AddressFormatter struct {
+	// Style holds the style to display addresses in.
+	Style AddressStyle
+
+	// VendorLookup holds an optional function to look up the vendor of a device.
+	VendorLookup VendorLookupFunc
+}
+
+// Format returns the address formatted in the formatter's style.
+func (f AddressFormatter) Format(address MacAddress) string {
+	return address.Format(f.Style)
+}
+
+// Vendor returns the name of the vendor of the device with the provided address.
+// If no vendor lookup function is set, or the vendor is not known, false is returned.
+func (f AddressFormatter) Vendor(address MacAddress) (string, bool) {
+	if f.VendorLookup == nil {
+		return "", false
+	}
+
+	return f.VendorLookup(address.OUI())
+}
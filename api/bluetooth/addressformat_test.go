@@ -0,0 +1,70 @@
+package bluetooth
+
+import "testing"
+
+func TestAddressFormatterFormat(t *testing.T) {
+	tests := []struct {
+		style AddressStyle
+		want  string
+	}{
+		{AddressStyleColon, "11:22:33:AA:BB:CC"},
+		{AddressStyleColonLower, "11:22:33:aa:bb:cc"},
+		{AddressStyleHyphen, "11-22-33-AA-BB-CC"},
+		{AddressStyleHyphenLower, "11-22-33-aa-bb-cc"},
+		{AddressStyleDotted, "1122.33AA.BBCC"},
+		{AddressStyleDottedLower, "1122.33aa.bbcc"},
+		{AddressStylePlain, "112233AABBCC"},
+		{AddressStylePlainLower, "112233aabbcc"},
+		{AddressStyle(-1), "11:22:33:AA:BB:CC"},
+	}
+
+	address, err := ParseMAC("11:22:33:AA:BB:CC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := (AddressFormatter{Style: tt.style}).Format(address); got != tt.want {
+				t.Errorf("Format() with style %d = %q, want %q", tt.style, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddressFormatterVendor(t *testing.T) {
+	vendors := map[[3]byte]string{
+		{0x11, 0x22, 0x33}: "Example Vendor",
+	}
+
+	lookup := func(oui [3]byte) (string, bool) {
+		vendor, ok := vendors[oui]
+		return vendor, ok
+	}
+
+	tests := []struct {
+		name       string
+		address    string
+		lookup     VendorLookupFunc
+		wantVendor string
+		wantOK     bool
+	}{
+		{"known vendor", "11:22:33:AA:BB:CC", lookup, "Example Vendor", true},
+		{"unknown vendor", "44:55:66:AA:BB:CC", lookup, "", false},
+		{"no lookup", "11:22:33:AA:BB:CC", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := ParseMAC(tt.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			vendor, ok := AddressFormatter{VendorLookup: tt.lookup}.Vendor(address)
+			if vendor != tt.wantVendor || ok != tt.wantOK {
+				t.Errorf("Vendor() = (%q, %v), want (%q, %v)", vendor, ok, tt.wantVendor, tt.wantOK)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package bluetooth
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,122 @@ type SessionAuthorizer interface {
 	AuthorizeDevicePairing
 }
 
+// AuthAuditor describes an interface to audit authorization decisions.
+// If a [SessionAuthorizer] implements this interface, it is invoked after each authorizer
+// callback, with the request details and the decision made. The auditor runs alongside the
+// normal authorization flow, and cannot alter the decision.
+type AuthAuditor interface {
+	AuditAuthorization(record AuthAuditRecord)
+}
+
+// AgentCapability describes the input and output capabilities of the authentication agent,
+// which determines the pairing method that is used with a device.
+type AgentCapability string
+
+// The different agent capability types.
+const (
+	CapabilityDisplayOnly     AgentCapability = "DisplayOnly"
+	CapabilityDisplayYesNo    AgentCapability = "DisplayYesNo"
+	CapabilityKeyboardOnly    AgentCapability = "KeyboardOnly"
+	CapabilityNoInputNoOutput AgentCapability = "NoInputNoOutput"
+	CapabilityKeyboardDisplay AgentCapability = "KeyboardDisplay"
+)
+
+// AuthRequestType describes the type of an authorization request.
+type AuthRequestType string
+
+// The different authorization request types.
+const (
+	AuthRequestDisplayPinCode    AuthRequestType = "display-pincode"
+	AuthRequestDisplayPasskey    AuthRequestType = "display-passkey"
+	AuthRequestConfirmPasskey    AuthRequestType = "confirm-passkey"
+	AuthRequestAuthorizePairing  AuthRequestType = "authorize-pairing"
+	AuthRequestAuthorizeService  AuthRequestType = "authorize-service"
+	AuthRequestAuthorizeTransfer AuthRequestType = "authorize-transfer"
+)
+
+// AuthAuditRecord holds the details of an authorization request, and the decision made for it.
+type AuthAuditRecord struct {
+	DeviceAddress
+
+	// Time holds the time at which the decision was made.
+	Time time.Time `json:"time" doc:"The time at which the decision was made."`
+
+	// Request holds the type of the authorization request.
+	Request AuthRequestType `json:"request" enum:"display-pincode,display-passkey,confirm-passkey,authorize-pairing,authorize-service,authorize-transfer" doc:"The type of the authorization request."`
+
+	// Pincode holds the pincode that was displayed, if any.
+	Pincode string `json:"pincode,omitempty" doc:"The pincode that was displayed, if any."`
+
+	// Passkey holds the passkey that was displayed or confirmed, if any.
+	Passkey uint32 `json:"passkey,omitempty" doc:"The passkey that was displayed or confirmed, if any."`
+
+	// Entered holds the number of passkey digits that were entered, if any.
+	Entered uint16 `json:"entered,omitempty" doc:"The number of passkey digits that were entered, if any."`
+
+	// UUID holds the Bluetooth profile UUID of the service that was authorized, if any.
+	UUID uuid.UUID `json:"uuid,omitzero" doc:"The Bluetooth profile UUID of the service that was authorized, if any."`
+
+	// ObjectPush holds the file transfer data of the transfer that was authorized, if any.
+	ObjectPush ObjectPushData `json:"object_push,omitzero" doc:"The file transfer data of the transfer that was authorized, if any."`
+
+	// Authorized indicates whether the request was authorized.
+	Authorized bool `json:"authorized" doc:"Indicates whether the request was authorized."`
+
+	// TimedOut indicates whether the authorization request timed out.
+	TimedOut bool `json:"timed_out,omitempty" doc:"Indicates whether the authorization request timed out."`
+
+	// Error holds the error that was returned by the authorizer, if any.
+	Error string `json:"error,omitempty" doc:"The error that was returned by the authorizer, if any."`
+
+	// Err holds the error that was returned by the authorizer, if any.
+	Err error `json:"-"`
+}
+
+// auditedAuthorizer describes an authorizer which is audited by an auditor.
+type auditedAuthorizer struct {
+	SessionAuthorizer
+	AuthAuditor
+}
+
+// NewAuditedAuthorizer returns a session authorizer, whose decisions are recorded by the provided auditor.
+// If the authorizer is nil, the [DefaultAuthorizer] is used. If the auditor is nil, the authorizer
+// is returned as is.
+func NewAuditedAuthorizer(authorizer SessionAuthorizer, auditor AuthAuditor) SessionAuthorizer {
+	if authorizer == nil {
+		authorizer = DefaultAuthorizer{}
+	}
+
+	if auditor == nil {
+		return authorizer
+	}
+
+	return auditedAuthorizer{authorizer, auditor}
+}
+
+// AuditAuthorization records the authorization decision (err) using the authorizer's auditor,
+// if the authorizer implements [AuthAuditor]. The authorizer is usually a [SessionAuthorizer],
+// or one of its constituent interfaces. The timeout is the authentication timeout that was
+// provided to the authorizer callback, and is used to determine whether the request timed out.
+func AuditAuthorization(authorizer any, timeout AuthTimeout, record AuthAuditRecord, err error) {
+	auditor, ok := authorizer.(AuthAuditor)
+	if !ok || auditor == nil {
+		return
+	}
+
+	record.Time = time.Now()
+	record.Authorized = err == nil
+	record.TimedOut = errors.Is(err, context.DeadlineExceeded) ||
+		(timeout.Context != nil && errors.Is(timeout.Err(), context.DeadlineExceeded))
+
+	if err != nil {
+		record.Err = err
+		record.Error = err.Error()
+	}
+
+	auditor.AuditAuthorization(record)
+}
+
 // AuthTimeout describes an authentication timeout duration.
 // The context value is created with 'context.WithTimeout()'.
 type AuthTimeout struct {
@@ -0,0 +1,107 @@
+package bluetooth
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testDecision describes the decision that is made by the test authorizer.
+type testDecision int
+
+const (
+	decisionAccept testDecision = iota
+	decisionReject
+	decisionTimeout
+)
+
+// testAuthorizer makes the same decision for every authorization request.
+type testAuthorizer struct {
+	DefaultAuthorizer
+	decision testDecision
+}
+
+func (a testAuthorizer) AuthorizePairing(timeout AuthTimeout, _ DeviceAddress) error {
+	switch a.decision {
+	case decisionReject:
+		return errors.New("pairing rejected")
+
+	case decisionTimeout:
+		<-timeout.Done()
+		return timeout.Err()
+	}
+
+	return nil
+}
+
+// testAuditor records every audited decision.
+type testAuditor struct {
+	records []AuthAuditRecord
+	mu      sync.Mutex
+}
+
+func (a *testAuditor) AuditAuthorization(record AuthAuditRecord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.records = append(a.records, record)
+}
+
+func TestAuditAuthorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		decision       testDecision
+		wantAuthorized bool
+		wantTimedOut   bool
+	}{
+		{name: "accept", decision: decisionAccept, wantAuthorized: true},
+		{name: "reject", decision: decisionReject},
+		{name: "timeout", decision: decisionTimeout, wantTimedOut: true},
+	}
+
+	address := NewDeviceAddress(MacAddress{1, 2, 3, 4, 5, 6}, MacAddress{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditor := &testAuditor{}
+			authorizer := NewAuditedAuthorizer(testAuthorizer{decision: tt.decision}, auditor)
+
+			timeout := NewAuthTimeout(10 * time.Millisecond)
+			defer timeout.Cancel()
+
+			err := authorizer.AuthorizePairing(timeout, address)
+			if (err == nil) != tt.wantAuthorized {
+				t.Fatalf("AuthorizePairing() error = %v, want authorized %v", err, tt.wantAuthorized)
+			}
+
+			AuditAuthorization(authorizer, timeout, AuthAuditRecord{DeviceAddress: address, Request: AuthRequestAuthorizePairing}, err)
+
+			if len(auditor.records) != 1 {
+				t.Fatalf("auditor received %d records, want 1", len(auditor.records))
+			}
+
+			record := auditor.records[0]
+			switch {
+			case record.DeviceAddress != address || record.Request != AuthRequestAuthorizePairing:
+				t.Errorf("record is for %s (%s), want %s (%s)", record.Address, record.Request, address.Address, AuthRequestAuthorizePairing)
+
+			case record.Authorized != tt.wantAuthorized || record.TimedOut != tt.wantTimedOut:
+				t.Errorf("record authorized = %v, timed out = %v, want %v, %v", record.Authorized, record.TimedOut, tt.wantAuthorized, tt.wantTimedOut)
+
+			case (record.Err != nil) != (err != nil) || (err != nil && record.Error != err.Error()):
+				t.Errorf("record error = %q, want %v", record.Error, err)
+
+			case record.Time.IsZero():
+				t.Error("record time is not set")
+			}
+		})
+	}
+}
+
+func TestAuditAuthorizationWithoutAuditor(t *testing.T) {
+	// An authorizer which does not implement the auditor interface is never audited,
+	// and the decision is unaffected.
+	AuditAuthorization(testAuthorizer{}, NewAuthTimeout(time.Second), AuthAuditRecord{}, nil)
+	AuditAuthorization(NewAuditedAuthorizer(nil, nil), NewAuthTimeout(time.Second), AuthAuditRecord{}, nil)
+}
@@ -1,6 +1,9 @@
 package bluetooth
 
 import (
+	"context"
+	"time"
+
 	"github.com/bluetuith-org/bluetooth-classic/api/optional"
 	"github.com/google/uuid"
 )
@@ -20,6 +23,18 @@ type Device interface {
 	// Disconnect will disconnect the bluetooth device from the adapter.
 	Disconnect() error
 
+	// PairAndConnect will attempt to pair a bluetooth device, wait for its services to be
+	// resolved, and then connect the device to the adapter. Any steps that are already complete,
+	// for example if the device is already paired, are skipped. If a step fails, the returned error
+	// matches [errorkinds.ErrDevicePairing], [errorkinds.ErrDeviceServicesResolve] or
+	// [errorkinds.ErrDeviceConnecting] respectively, so that partial states can be determined.
+	PairAndConnect(ctx context.Context) error
+
+	// PairWithCapability will attempt to pair a bluetooth device, using the provided
+	// authentication capability for this pairing attempt only. The context (ctx) can be
+	// provided in case this function call needs to be cancelled.
+	PairWithCapability(ctx context.Context, capability AgentCapability) error
+
 	// ConnectProfile will attempt to connect an already paired bluetooth device
 	// to an adapter, using a specific Bluetooth profile UUID .
 	ConnectProfile(profileUUID uuid.UUID) error
@@ -41,6 +56,69 @@ type Device interface {
 
 	// Properties returns all the properties of the device.
 	Properties() (DeviceData, error)
+
+	// SetProperty sets a raw property of the device.
+	// This is an unstable API, which is meant to access properties that are not yet exposed
+	// by the typed API. The property name and value types are specific to the backend.
+	// Currently is valid only on Linux.
+	SetProperty(name string, value any) error
+
+	// GetProperty gets a raw property of the device.
+	// This is an unstable API, which is meant to access properties that are not yet exposed
+	// by the typed API. The property name and value types are specific to the backend.
+	// Currently is valid only on Linux.
+	GetProperty(name string) (any, error)
+
+	// ConnectionParameters returns the negotiated connection parameters of the device.
+	// The parameters are only available for connected LE or dual-mode devices, and
+	// [errorkinds.ErrNotSupported] is returned for classic-only links.
+	ConnectionParameters() (ConnParams, error)
+}
+
+// ConnParams holds the negotiated connection parameters of a device link.
+type ConnParams struct {
+	// Interval holds the connection interval, in units of 1.25 milliseconds.
+	Interval uint16 `json:"interval" doc:"The connection interval, in units of 1.25 milliseconds."`
+
+	// Latency holds the peripheral latency, in number of connection events.
+	Latency uint16 `json:"latency" doc:"The peripheral latency, in number of connection events."`
+
+	// SupervisionTimeout holds the supervision timeout, in units of 10 milliseconds.
+	SupervisionTimeout uint16 `json:"supervision_timeout" doc:"The supervision timeout, in units of 10 milliseconds."`
+}
+
+// IntervalDuration returns the connection interval as a duration.
+func (c ConnParams) IntervalDuration() time.Duration {
+	return time.Duration(c.Interval) * 1250 * time.Microsecond
+}
+
+// SupervisionTimeoutDuration returns the supervision timeout as a duration.
+func (c ConnParams) SupervisionTimeoutDuration() time.Duration {
+	return time.Duration(c.SupervisionTimeout) * 10 * time.Millisecond
+}
+
+// DefaultLinkQualityInterval is the default interval at which the link quality of a device is sampled.
+const DefaultLinkQualityInterval = time.Second
+
+// LinkQuality holds a sample of the quality metrics of a device link.
+// Each metric is only set if it is available at the time of sampling.
+type LinkQuality struct {
+	// Time holds the time at which the sample was taken.
+	Time time.Time `json:"time" doc:"The time at which the sample was taken."`
+
+	// RSSI holds the received signal strength of the device, in dBm.
+	RSSI optional.Optional[int16] `json:"rssi,omitzero" doc:"The received signal strength of the device, in dBm."`
+
+	// TxPower holds the transmit power level of the link, in dBm.
+	TxPower optional.Optional[int16] `json:"tx_power,omitzero" doc:"The transmit power level of the link, in dBm."`
+
+	// LinkQuality holds the quality of the link, from 0 (worst) to 255 (best).
+	LinkQuality optional.Optional[uint8] `json:"link_quality,omitzero" doc:"The quality of the link, from 0 (worst) to 255 (best)."`
+}
+
+// HasMetrics returns whether any quality metric was available when the sample was taken.
+func (l LinkQuality) HasMetrics() bool {
+	return !l.RSSI.IsZero() || !l.TxPower.IsZero() || !l.LinkQuality.IsZero()
 }
 
 // AuthorizeDevicePairing describes an authentication interface, which is used
@@ -92,6 +170,16 @@ type DeviceData struct {
 	// legacy or simple pairing will occur if pairing is initiated.
 	LegacyPairing bool `json:"legacy_pairing,omitempty" codec:"LegacyPairing,omitempty" doc:"Indicates whether the device only supports the pre-2.1 pairing mechanism. This property is useful during device discovery to anticipate whether legacy or simple pairing will occur if pairing is initiated."`
 
+	// Connectable indicates whether a connection to the device can be established
+	// over BR/EDR (Bluetooth Classic). This property is useful during device discovery
+	// to hide devices, like LE-only beacons, which cannot be connected to.
+	Connectable bool `json:"connectable,omitempty" codec:"Connectable,omitempty" doc:"Indicates whether a connection to the device can be established over BR/EDR (Bluetooth Classic). This property is useful during device discovery to hide devices, like LE-only beacons, which cannot be connected to."`
+
+	// AdvertisingFlags holds the advertising data flags of the device.
+	// This is only available if the device was discovered via an LE advertisement,
+	// and is currently valid only on Linux.
+	AdvertisingFlags []byte `json:"advertising_flags,omitempty" codec:"AdvertisingFlags,omitempty" doc:"The advertising data flags of the device. This is only available if the device was discovered via an LE advertisement, and is currently valid only on Linux."`
+
 	DeviceEventData
 }
 
@@ -135,6 +223,10 @@ type DeviceEventData struct {
 	// RSSI indicates the signal strength of the device.
 	RSSI optional.Optional[int16] `json:"rssi,omitzero" codec:"RSSI,omitempty" doc:"Indicates the signal strength of the device."`
 
+	// ServicesResolved indicates if the services (Bluetooth profiles) of the device
+	// have been resolved. The UUIDs of the device are complete only after this is set.
+	ServicesResolved optional.Optional[bool] `json:"services_resolved,omitzero" codec:"ServicesResolved,omitempty" doc:"Indicates if the services (Bluetooth profiles) of the device have been resolved. The UUIDs of the device are complete only after this is set."`
+
 	// Percentage holds the battery percentage of the device.
 	Percentage optional.Optional[uint32] `json:"percentage,omitzero" codec:"Percentage,omitempty" minimum:"0" maximum:"100" doc:"The battery percentage of the device."`
 
@@ -142,6 +234,21 @@ type DeviceEventData struct {
 	UUIDs uuid.UUIDs `json:"uuids,omitempty" codec:"UUIDs,omitempty" doc:"The device-supported Bluetooth profile UUIDs."`
 }
 
+// advertisingFlagBREDRNotSupported is the advertising data flag which indicates
+// that the device does not support BR/EDR (Bluetooth Classic).
+const advertisingFlagBREDRNotSupported = 0x04
+
+// ConnectableFromAdvertisingFlags parses the advertising data flags of a device and returns
+// whether the device can be connected to over BR/EDR. If no flags are provided, the device
+// is assumed to have been discovered via an inquiry, and is therefore connectable.
+func ConnectableFromAdvertisingFlags(flags []byte) bool {
+	if len(flags) == 0 {
+		return true
+	}
+
+	return flags[0]&advertisingFlagBREDRNotSupported == 0
+}
+
 // DeviceTypeFromClass parses the device class and returns its type.
 //
 //gocyclo:ignore
@@ -0,0 +1,52 @@
+package bluetooth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConnectableFromAdvertisingFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags []byte
+		want  bool
+	}{
+		{"no flags", nil, true},
+		{"general discoverable, BR/EDR supported", []byte{0x02}, true},
+		{"dual-mode controller and host", []byte{0x1a}, true},
+		{"LE-only, general discoverable", []byte{0x06}, false},
+		{"BR/EDR not supported", []byte{0x04}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConnectableFromAdvertisingFlags(tt.flags); got != tt.want {
+				t.Errorf("ConnectableFromAdvertisingFlags(%v) = %v, want %v", tt.flags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnParamsDurations(t *testing.T) {
+	tests := []struct {
+		name                   string
+		params                 ConnParams
+		wantInterval, wantTime time.Duration
+	}{
+		{"zero", ConnParams{}, 0, 0},
+		{"minimum", ConnParams{Interval: 6, SupervisionTimeout: 10}, 7500 * time.Microsecond, 100 * time.Millisecond},
+		{"typical peripheral", ConnParams{Interval: 24, Latency: 4, SupervisionTimeout: 72}, 30 * time.Millisecond, 720 * time.Millisecond},
+		{"maximum", ConnParams{Interval: 3200, SupervisionTimeout: 3200}, 4 * time.Second, 32 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.params.IntervalDuration(); got != tt.wantInterval {
+				t.Errorf("IntervalDuration() = %v, want %v", got, tt.wantInterval)
+			}
+			if got := tt.params.SupervisionTimeoutDuration(); got != tt.wantTime {
+				t.Errorf("SupervisionTimeoutDuration() = %v, want %v", got, tt.wantTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package bluetooth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Southclaws/fault/fctx"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+// BluetoothError describes an error that was published to the error event stream.
+type BluetoothError struct {
+	// Time holds the time at which the error was published.
+	Time time.Time `json:"time" doc:"The time at which the error was published."`
+
+	// Context holds the context of the error, for example the location
+	// at which the error occurred, or the address of the associated device.
+	Context map[string]string `json:"context,omitempty" doc:"The context of the error, for example the location at which the error occurred, or the address of the associated device."`
+
+	errorkinds.GenericError
+}
+
+// ErrorHistory describes a bounded history of published errors.
+// Errors are recorded within the history only when they are published
+// via an [ErrorEventGroup] which holds the history.
+type ErrorHistory struct {
+	errors []BluetoothError
+	start  int
+	size   int
+
+	mu sync.Mutex
+}
+
+// NewErrorHistory returns a new error history, which keeps at most 'capacity' errors.
+// If the capacity is zero or lesser, errors are not recorded.
+func NewErrorHistory(capacity int) *ErrorHistory {
+	return &ErrorHistory{
+		errors: make([]BluetoothError, max(0, capacity)),
+	}
+}
+
+// ErrorEvents returns an event interface to publish and subscribe to error events. Errors which
+// are published via the returned event interface are recorded within the error history.
+func (h *ErrorHistory) ErrorEvents() ErrorEventGroup {
+	group := ErrorEvents()
+	group.history = h
+
+	return group
+}
+
+// RecentErrors returns the 'n' most recently published errors, in the order in which they were
+// published. If 'n' is zero or lesser, all the errors within the error history are returned.
+func (h *ErrorHistory) RecentErrors(n int) []BluetoothError {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n <= 0 || n > h.size {
+		n = h.size
+	}
+
+	errors := make([]BluetoothError, 0, n)
+	for i := h.size - n; i < h.size; i++ {
+		errors = append(errors, h.errors[(h.start+i)%len(h.errors)])
+	}
+
+	return errors
+}
+
+// SetCapacity sets the maximum number of errors that are kept in the error history.
+// If the history holds more errors than the new capacity, the oldest errors are discarded.
+// If the capacity is zero or lesser, errors are not recorded.
+func (h *ErrorHistory) SetCapacity(capacity int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	capacity = max(0, capacity)
+
+	errors := make([]BluetoothError, capacity)
+
+	size := min(h.size, capacity)
+	for i := range size {
+		errors[i] = h.errors[(h.start+h.size-size+i)%len(h.errors)]
+	}
+
+	h.errors = errors
+	h.start = 0
+	h.size = size
+}
+
+// record adds the error to the error history, and discards
+// the oldest error if the history is full.
+func (h *ErrorHistory) record(err errorkinds.GenericError) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.errors) == 0 {
+		return
+	}
+
+	e := BluetoothError{
+		Time:         time.Now(),
+		Context:      fctx.Unwrap(err),
+		GenericError: err,
+	}
+
+	if h.size < len(h.errors) {
+		h.errors[(h.start+h.size)%len(h.errors)] = e
+		h.size++
+
+		return
+	}
+
+	h.errors[h.start] = e
+	h.start = (h.start + 1) % len(h.errors)
+}
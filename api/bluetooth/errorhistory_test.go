@@ -0,0 +1,112 @@
+package bluetooth
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+func publishErrors(group ErrorEventGroup, from, to int) {
+	for i := from; i < to; i++ {
+		group.PublishAdded(errorkinds.GenericError{Errors: errors.New(strconv.Itoa(i))})
+	}
+}
+
+func errorMessages(errs []BluetoothError) []string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Errors.Error())
+	}
+
+	return messages
+}
+
+func TestErrorHistory(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int
+		published int
+		n         int
+		want      []string
+	}{
+		{"empty", 3, 0, 0, []string{}},
+		{"partially filled", 3, 2, 0, []string{"0", "1"}},
+		{"exactly full", 3, 3, 0, []string{"0", "1", "2"}},
+		{"wraps around once", 3, 4, 0, []string{"1", "2", "3"}},
+		{"wraps around several times", 3, 10, 0, []string{"7", "8", "9"}},
+		{"most recent after wraparound", 3, 5, 2, []string{"3", "4"}},
+		{"n greater than size", 3, 2, 5, []string{"0", "1"}},
+		{"negative n", 3, 4, -1, []string{"1", "2", "3"}},
+		{"zero capacity", 0, 3, 0, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := NewErrorHistory(tt.capacity)
+			publishErrors(history.ErrorEvents(), 0, tt.published)
+
+			if got := errorMessages(history.RecentErrors(tt.n)); !slices.Equal(got, tt.want) {
+				t.Errorf("RecentErrors(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorHistorySetCapacity(t *testing.T) {
+	tests := []struct {
+		name      string
+		published int
+		capacity  int
+		want      []string
+	}{
+		{"shrink keeps most recent", 5, 2, []string{"3", "4"}},
+		{"grow keeps order", 5, 6, []string{"1", "2", "3", "4"}},
+		{"disable", 5, 0, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := NewErrorHistory(4)
+			publishErrors(history.ErrorEvents(), 0, tt.published)
+
+			history.SetCapacity(tt.capacity)
+			if got := errorMessages(history.RecentErrors(0)); !slices.Equal(got, tt.want) {
+				t.Errorf("RecentErrors(0) = %v, want %v", got, tt.want)
+			}
+
+			// Errors which are published after the capacity is changed must
+			// be appended after the existing errors.
+			publishErrors(history.ErrorEvents(), tt.published, tt.published+1)
+
+			want := append(tt.want, strconv.Itoa(tt.published))
+			want = want[max(0, len(want)-tt.capacity):]
+			if got := errorMessages(history.RecentErrors(0)); !slices.Equal(got, want) {
+				t.Errorf("RecentErrors(0) after publish = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestErrorHistoryIsolation(t *testing.T) {
+	first, second := NewErrorHistory(4), NewErrorHistory(4)
+
+	publishErrors(first.ErrorEvents(), 0, 2)
+	publishErrors(second.ErrorEvents(), 2, 3)
+	publishErrors(ErrorEvents(), 3, 4)
+
+	if got, want := errorMessages(first.RecentErrors(0)), []string{"0", "1"}; !slices.Equal(got, want) {
+		t.Errorf("first history = %v, want %v", got, want)
+	}
+	if got, want := errorMessages(second.RecentErrors(0)), []string{"2"}; !slices.Equal(got, want) {
+		t.Errorf("second history = %v, want %v", got, want)
+	}
+
+	for _, err := range first.RecentErrors(0) {
+		if err.Time.IsZero() {
+			t.Error("recorded error has no time")
+		}
+	}
+}
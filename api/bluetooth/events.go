@@ -1,6 +1,11 @@
 package bluetooth
 
 import (
+	"cmp"
+	"reflect"
+	"slices"
+	"sync"
+
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 	"github.com/bluetuith-org/bluetooth-classic/api/eventbus"
 )
@@ -30,21 +35,76 @@ const (
 	EventActionRemoved EventAction = "removed"
 )
 
-// eventNames holds names of different events.
-var (
-	eventNames = map[EventID]string{
-		EventNone:        "",
-		EventError:       "error_event",
-		EventAdapter:     "adapter_event",
-		EventDevice:      "device_event",
-		EventObjectPush:  "file_transfer_event",
-		EventMediaPlayer: "media_player_event",
+// EventInfo describes an event that can be published to or subscribed from the event stream.
+type EventInfo struct {
+	// ID holds the event ID.
+	ID EventID `json:"event_id"`
+
+	// Name holds the name of the event.
+	Name string `json:"name"`
+
+	// Description holds a brief description of the event.
+	Description string `json:"description,omitempty"`
+
+	// AddedType holds the type name of the data that is published
+	// when an object is added.
+	AddedType string `json:"added_type,omitempty"`
+
+	// UpdatedType holds the type name of the data that is published
+	// when an object is updated or removed.
+	UpdatedType string `json:"updated_type,omitempty"`
+}
+
+// eventInfos holds the names, descriptions and payload types of different events.
+// Both the event names ([EventID.String]) and the event catalog ([EventCatalog]) are
+// derived from this table, so every event ID apart from 'EventNone' must have an entry here.
+var eventInfos = map[EventID]EventInfo{
+	EventError:          eventInfo[errorkinds.GenericError, emptyUpdatedDataEvent]("error_event", "Errors that occurred during the session."),
+	EventAdapter:        eventInfo[AdapterData, AdapterEventData]("adapter_event", "Adapters that were added to, updated within or removed from the system."),
+	EventDevice:         eventInfo[DeviceData, DeviceEventData]("device_event", "Devices that were added to, updated within or removed from an adapter."),
+	EventObjectPush:     eventInfo[ObjectPushData, ObjectPushEventData]("file_transfer_event", "File transfers that were queued, updated or removed."),
+	EventMediaPlayer:    eventInfo[MediaData, MediaData]("media_player_event", "Media player properties that were updated on a device."),
+	EventAuthentication: {Name: "authentication_event", Description: "Authentication requests, which are handled internally and forwarded to the session's authorizer."},
+}
+
+// EventCatalog returns a list of all known events, sorted by their IDs.
+// The catalog is provided by this package instead of the 'eventbus' package, since the
+// event bus is generic over event IDs, and cannot refer to the event IDs and payload types
+// that are defined here without an import cycle.
+func EventCatalog() []EventInfo {
+	events := make([]EventInfo, 0, len(eventInfos))
+	for id, info := range eventInfos {
+		info.ID = id
+		events = append(events, info)
 	}
-)
 
-// String returns the name of the event ID.
+	slices.SortFunc(events, func(a, b EventInfo) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return events
+}
+
+// eventInfo returns an event name and description along with the names of its payload types.
+func eventInfo[N NewDataEvents, U UpdatedDataEvents](name, description string) EventInfo {
+	info := EventInfo{
+		Name:        name,
+		Description: description,
+		AddedType:   reflect.TypeFor[N]().String(),
+	}
+
+	if updated := reflect.TypeFor[U](); updated != reflect.TypeFor[emptyUpdatedDataEvent]() {
+		info.UpdatedType = updated.String()
+	}
+
+	return info
+}
+
+// String returns the name of the event ID, as listed in the event catalog ([EventCatalog]).
+// Every event ID has a name, including [EventAuthentication] ("authentication_event"),
+// apart from [EventNone] and unknown event IDs, whose name is an empty string.
 func (e EventID) String() string {
-	return eventNames[e]
+	return eventInfos[e].Name
 }
 
 // String returns the name of the event ID.
@@ -90,6 +150,23 @@ type Event[T Events] struct {
 	Data T `json:"event_data,omitempty" doc:"The actual event data."`
 }
 
+// RawEvent describes an event frame that was received from a server, before it was decoded
+// into a typed event. Raw events are mainly used by diagnostic tools to inspect the wire protocol.
+type RawEvent struct {
+	// ID holds the event ID, which may not be a known event ID.
+	// This is zero if the frame could not be decoded.
+	ID EventID `json:"event_id"`
+
+	// Action holds the event action.
+	Action EventAction `json:"event_action,omitempty"`
+
+	// Frame holds the complete frame, as it was received from the server.
+	Frame []byte `json:"frame"`
+
+	// Err holds the error that occurred while decoding the frame, if any.
+	Err error `json:"-"`
+}
+
 // EventGroup holds a set of events that can be added ([NewDataEvents]) or updated ([UpdatedDataEvents]) for a particular event ID ([EventID])
 type EventGroup[N NewDataEvents, U UpdatedDataEvents] struct {
 	// ID holds the event ID.
@@ -103,6 +180,75 @@ type Subscriber[N NewDataEvents, U UpdatedDataEvents] struct {
 	Done                         chan struct{}
 
 	Unsubscribe eventbus.UnsubFunc
+
+	pause subscriberPause
+}
+
+// PauseMode describes how events are handled while a subscriber is paused.
+type PauseMode struct {
+	// BufferSize holds the maximum number of events to buffer while the subscriber
+	// is paused. If the buffer is full, the oldest buffered event is dropped.
+	// If BufferSize is zero, all events are dropped while the subscriber is paused.
+	BufferSize int
+}
+
+// PauseDropEvents is a pause mode which drops all events while the subscriber is paused.
+var PauseDropEvents = PauseMode{}
+
+// subscriberPause holds the paused state of a subscriber.
+type subscriberPause struct {
+	paused   bool
+	flushing bool
+	mode     PauseMode
+	pending  []func()
+
+	resumed chan struct{}
+	stop    chan struct{}
+	flushed chan struct{}
+
+	stopOnce sync.Once
+	mu       sync.Mutex
+}
+
+// Pause stops forwarding events to the subscriber's channels, without unsubscribing
+// from the event group. Events that are published while the subscriber is paused are
+// dropped or buffered according to the provided mode. Calling Pause on an already paused
+// subscriber only updates its pause mode.
+func (s *Subscriber[N, U]) Pause(mode PauseMode) {
+	s.pause.mu.Lock()
+	defer s.pause.mu.Unlock()
+
+	s.pause.paused = true
+	s.pause.mode = mode
+	s.pause.mode.BufferSize = max(0, mode.BufferSize)
+
+	if excess := len(s.pause.pending) - s.pause.mode.BufferSize; excess > 0 {
+		s.pause.pending = slices.Delete(s.pause.pending, 0, excess)
+	}
+}
+
+// Resume resumes forwarding events to the subscriber's channels. Any events that were
+// buffered while the subscriber was paused are delivered first, in the order in which
+// they were published. The buffered events are delivered from the subscriber's own goroutine,
+// so that a slow subscriber does not stall the delivery of events to other subscribers.
+func (s *Subscriber[N, U]) Resume() {
+	s.pause.mu.Lock()
+	s.pause.paused = false
+	s.pause.flushing = len(s.pause.pending) > 0
+	s.pause.mu.Unlock()
+
+	select {
+	case s.pause.resumed <- struct{}{}:
+	default:
+	}
+}
+
+// Paused returns whether the subscriber is paused.
+func (s *Subscriber[N, U]) Paused() bool {
+	s.pause.mu.Lock()
+	defer s.pause.mu.Unlock()
+
+	return s.pause.paused
 }
 
 // PublishAdded publishes an event with the 'added' action, which is to indicate that a particular object was added to
@@ -133,7 +279,15 @@ func (e EventGroup[N, U]) Subscribe() (*Subscriber[N, U], bool) {
 		RemovedEvents: make(chan U, 1),
 		UpdatedEvents: make(chan U, 1),
 		Done:          make(chan struct{}, 1),
-		Unsubscribe:   id.Unsubscribe,
+		pause: subscriberPause{
+			resumed: make(chan struct{}, 1),
+			stop:    make(chan struct{}),
+			flushed: make(chan struct{}),
+		},
+	}
+	sub.Unsubscribe = func() {
+		sub.pause.stopOnce.Do(func() { close(sub.pause.stop) })
+		id.Unsubscribe()
 	}
 
 	if !id.IsActive() {
@@ -143,36 +297,40 @@ func (e EventGroup[N, U]) Subscribe() (*Subscriber[N, U], bool) {
 		goto Token
 	}
 
+	go sub.pause.flusher()
+
 	go func() {
-		for data := range id.C {
-			switch v := data.(type) {
-			case Event[N]:
-				if v.Action != EventActionAdded {
-					continue
+	Listen:
+		for {
+			select {
+			case data, ok := <-id.C:
+				if !ok {
+					break Listen
 				}
 
-				select {
-				case sub.AddedEvents <- v.Data:
-				default:
-				}
+				switch v := data.(type) {
+				case Event[N]:
+					if v.Action != EventActionAdded {
+						continue
+					}
 
-			case Event[U]:
-				var ch chan U
+					forwardEvent(&sub.pause, sub.AddedEvents, v.Data)
 
-				switch v.Action {
-				case EventActionUpdated:
-					ch = sub.UpdatedEvents
+				case Event[U]:
+					var ch chan U
 
-				case EventActionRemoved:
-					ch = sub.RemovedEvents
+					switch v.Action {
+					case EventActionUpdated:
+						ch = sub.UpdatedEvents
 
-				default:
-					continue
-				}
+					case EventActionRemoved:
+						ch = sub.RemovedEvents
 
-				select {
-				case ch <- v.Data:
-				default:
+					default:
+						continue
+					}
+
+					forwardEvent(&sub.pause, ch, v.Data)
 				}
 			}
 		}
@@ -182,6 +340,11 @@ func (e EventGroup[N, U]) Subscribe() (*Subscriber[N, U], bool) {
 		default:
 		}
 
+		// Stop the flusher before closing the channels, so that
+		// no buffered events are sent to closed channels.
+		sub.pause.stopOnce.Do(func() { close(sub.pause.stop) })
+		<-sub.pause.flushed
+
 		close(sub.AddedEvents)
 		close(sub.RemovedEvents)
 		close(sub.UpdatedEvents)
@@ -191,6 +354,80 @@ Token:
 	return &sub, id.IsActive()
 }
 
+// forwardEvent sends the event data to the provided channel if the subscriber is not paused.
+// If the subscriber is paused, or buffered events are still being delivered, the event data
+// is either buffered or dropped, so that the dispatching goroutine never blocks.
+func forwardEvent[T Events](p *subscriberPause, ch chan T, data T) {
+	p.mu.Lock()
+	if p.paused || p.flushing {
+		// While paused, the oldest buffered event is dropped if the buffer is full. While the
+		// buffered events are being delivered, the new event is dropped instead, similar to
+		// when the subscriber cannot keep up with the events.
+		full := len(p.pending) >= p.mode.BufferSize
+		if full && p.paused && p.mode.BufferSize > 0 {
+			p.pending = slices.Delete(p.pending, 0, 1)
+			full = false
+		}
+
+		if !full {
+			p.pending = append(p.pending, func() {
+				select {
+				case ch <- data:
+				case <-p.stop:
+				}
+			})
+		}
+
+		p.mu.Unlock()
+
+		return
+	}
+	p.mu.Unlock()
+
+	select {
+	case ch <- data:
+	default:
+	}
+}
+
+// flusher delivers the buffered events each time the subscriber is resumed, until the subscriber
+// has unsubscribed. Since the subscriber may not be ready to receive the buffered events immediately
+// after resuming, each delivery waits until the event is received or the subscriber has unsubscribed.
+func (p *subscriberPause) flusher() {
+	defer close(p.flushed)
+
+	for {
+		select {
+		case <-p.resumed:
+		case <-p.stop:
+			return
+		}
+
+		for {
+			p.mu.Lock()
+			if p.paused || len(p.pending) == 0 {
+				p.flushing = false
+				p.mu.Unlock()
+
+				break
+			}
+
+			deliver := p.pending[0]
+			p.pending = p.pending[1:]
+			p.flushing = true
+			p.mu.Unlock()
+
+			deliver()
+
+			select {
+			case <-p.stop:
+				return
+			default:
+			}
+		}
+	}
+}
+
 // AdapterEvents returns an event interface to subscribe to adapter events.
 func AdapterEvents() EventGroup[AdapterData, AdapterEventData] {
 	return EventGroup[AdapterData, AdapterEventData]{ID: EventAdapter}
@@ -211,7 +448,28 @@ func ObjectPushEvents() EventGroup[ObjectPushData, ObjectPushEventData] {
 	return EventGroup[ObjectPushData, ObjectPushEventData]{ID: EventObjectPush}
 }
 
-// ErrorEvents returns an event interface to subscribe to error events.
-func ErrorEvents() EventGroup[errorkinds.GenericError, emptyUpdatedDataEvent] {
-	return EventGroup[errorkinds.GenericError, emptyUpdatedDataEvent]{ID: EventError}
+// ErrorEventGroup describes an event interface to publish and subscribe to error events.
+// If the group holds an error history, published errors are recorded within it.
+type ErrorEventGroup struct {
+	EventGroup[errorkinds.GenericError, emptyUpdatedDataEvent]
+
+	history *ErrorHistory
+}
+
+// PublishAdded publishes an error event, and records the error within the error history of the group.
+func (e ErrorEventGroup) PublishAdded(data errorkinds.GenericError) {
+	if e.history != nil {
+		e.history.record(data)
+	}
+
+	e.EventGroup.PublishAdded(data)
+}
+
+// ErrorEvents returns an event interface to subscribe to error events. Errors which are published
+// via the returned event interface are not recorded within any error history. To record published
+// errors, use the event interface which is returned by [ErrorHistory.ErrorEvents] instead.
+func ErrorEvents() ErrorEventGroup {
+	return ErrorEventGroup{
+		EventGroup: EventGroup[errorkinds.GenericError, emptyUpdatedDataEvent]{ID: EventError},
+	}
 }
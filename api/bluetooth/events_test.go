@@ -0,0 +1,196 @@
+package bluetooth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventCatalog(t *testing.T) {
+	tests := []struct {
+		id          EventID
+		name        string
+		addedType   string
+		updatedType string
+	}{
+		{EventError, "error_event", "errorkinds.GenericError", ""},
+		{EventAdapter, "adapter_event", "bluetooth.AdapterData", "bluetooth.AdapterEventData"},
+		{EventDevice, "device_event", "bluetooth.DeviceData", "bluetooth.DeviceEventData"},
+		{EventObjectPush, "file_transfer_event", "bluetooth.ObjectPushData", "bluetooth.ObjectPushEventData"},
+		{EventMediaPlayer, "media_player_event", "bluetooth.MediaData", "bluetooth.MediaData"},
+		{EventAuthentication, "authentication_event", "", ""},
+	}
+
+	catalog := EventCatalog()
+	if len(catalog) != len(tests) {
+		t.Fatalf("catalog has %d events, want %d", len(catalog), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := catalog[i]
+
+			if info.ID != tt.id {
+				t.Errorf("ID = %d, want %d", info.ID, tt.id)
+			}
+			if info.Name != tt.name || tt.id.String() != tt.name {
+				t.Errorf("Name = %q, String() = %q, want %q", info.Name, tt.id.String(), tt.name)
+			}
+			if info.Description == "" {
+				t.Error("Description is empty")
+			}
+			if info.AddedType != tt.addedType {
+				t.Errorf("AddedType = %q, want %q", info.AddedType, tt.addedType)
+			}
+			if info.UpdatedType != tt.updatedType {
+				t.Errorf("UpdatedType = %q, want %q", info.UpdatedType, tt.updatedType)
+			}
+		})
+	}
+}
+
+func TestEventCatalogCoversAllEventIDs(t *testing.T) {
+	for id := EventNone + 1; id <= EventAuthentication; id++ {
+		if _, ok := eventInfos[id]; !ok {
+			t.Errorf("event ID %d has no catalog entry", id)
+		}
+	}
+
+	if name := EventNone.String(); name != "" {
+		t.Errorf("EventNone.String() = %q, want empty", name)
+	}
+}
+
+func TestSubscriberPause(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       PauseMode
+		published  []byte
+		afterwards byte
+		want       []byte
+	}{
+		{
+			name:       "drop",
+			mode:       PauseDropEvents,
+			published:  []byte{1, 2, 3},
+			afterwards: 4,
+			want:       nil,
+		},
+		{
+			name:       "buffer",
+			mode:       PauseMode{BufferSize: 5},
+			published:  []byte{1, 2, 3},
+			afterwards: 4,
+			want:       []byte{1, 2, 3},
+		},
+		{
+			name:       "buffer drops oldest",
+			mode:       PauseMode{BufferSize: 2},
+			published:  []byte{1, 2, 3},
+			afterwards: 4,
+			want:       []byte{2, 3},
+		},
+	}
+
+	events := DeviceEvents()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, ok := events.Subscribe()
+			if !ok {
+				t.Fatal("cannot subscribe")
+			}
+			defer sub.Unsubscribe()
+
+			sub.Pause(tt.mode)
+			if !sub.Paused() {
+				t.Fatal("subscriber is not paused")
+			}
+
+			for _, position := range tt.published {
+				events.PublishUpdated(deviceEvent(position))
+			}
+
+			// Wait for the published events to be dispatched before resuming.
+			time.Sleep(50 * time.Millisecond)
+			expectNoEvent(t, sub.UpdatedEvents)
+
+			sub.Resume()
+
+			for _, want := range tt.want {
+				if got := receiveEvent(t, sub.UpdatedEvents); got.Address[5] != want {
+					t.Fatalf("received event %d, want %d", got.Address[5], want)
+				}
+			}
+
+			expectNoEvent(t, sub.UpdatedEvents)
+
+			// Events are forwarded as usual once the buffered events are delivered.
+			events.PublishUpdated(deviceEvent(tt.afterwards))
+			if got := receiveEvent(t, sub.UpdatedEvents); got.Address[5] != tt.afterwards {
+				t.Fatalf("received event %d, want %d", got.Address[5], tt.afterwards)
+			}
+		})
+	}
+}
+
+func TestSubscriberResumeDoesNotStallOthers(t *testing.T) {
+	events := DeviceEvents()
+
+	slow, ok := events.Subscribe()
+	if !ok {
+		t.Fatal("cannot subscribe")
+	}
+	defer slow.Unsubscribe()
+
+	other, ok := events.Subscribe()
+	if !ok {
+		t.Fatal("cannot subscribe")
+	}
+	defer other.Unsubscribe()
+
+	slow.Pause(PauseMode{BufferSize: 5})
+	for position := range byte(5) {
+		events.PublishUpdated(deviceEvent(position))
+		receiveEvent(t, other.UpdatedEvents)
+	}
+
+	// The slow subscriber is resumed, but never reads its buffered events.
+	slow.Resume()
+
+	for position := range byte(3) {
+		events.PublishUpdated(deviceEvent(10 + position))
+		if got := receiveEvent(t, other.UpdatedEvents); got.Address[5] != 10+position {
+			t.Fatalf("received event %d, want %d", got.Address[5], 10+position)
+		}
+	}
+}
+
+// deviceEvent returns a device event, which is identified by the last byte of its address.
+func deviceEvent(id byte) DeviceEventData {
+	return DeviceEventData{DeviceAddress: DeviceAddress{Address: MacAddress{5: id}}}
+}
+
+func receiveEvent[T Events](t *testing.T, ch chan T) T {
+	t.Helper()
+
+	select {
+	case data := <-ch:
+		return data
+
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	panic("unreachable")
+}
+
+func expectNoEvent[T Events](t *testing.T, ch chan T) {
+	t.Helper()
+
+	select {
+	case data := <-ch:
+		t.Fatalf("received unexpected event %+v", data)
+
+	case <-time.After(50 * time.Millisecond):
+	}
+}
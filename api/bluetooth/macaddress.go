@@ -35,6 +35,55 @@ func (m *MacAddress) String() string {
 	return m.byteBuffer().String()
 }
 
+// Format returns a human-readable version of this MAC address in the provided style.
+// For example, the AddressStyleHyphenLower style formats the address as 11-22-33-aa-bb-cc.
+func (m *MacAddress) Format(style AddressStyle) string {
+	var (
+		separator string
+		groupSize = 1
+	)
+
+	switch style {
+	case AddressStyleColon, AddressStyleColonLower:
+		separator = ":"
+
+	case AddressStyleHyphen, AddressStyleHyphenLower:
+		separator = "-"
+
+	case AddressStyleDotted, AddressStyleDottedLower:
+		separator = "."
+		groupSize = 2
+
+	case AddressStylePlain, AddressStylePlainLower:
+
+	default:
+		return m.String()
+	}
+
+	digits := "0123456789ABCDEF"
+	if style.lowercase() {
+		digits = "0123456789abcdef"
+	}
+
+	s := bytes.NewBuffer(make([]byte, 0, MaxAddressStringLength))
+	for i := NumAddressBytes - 1; i >= 0; i-- {
+		if i != NumAddressBytes-1 && (NumAddressBytes-1-i)%groupSize == 0 {
+			s.WriteString(separator)
+		}
+
+		s.WriteByte(digits[m[i]>>4])
+		s.WriteByte(digits[m[i]&0x0f])
+	}
+
+	return s.String()
+}
+
+// OUI returns the organizationally unique identifier of this MAC address,
+// i.e the first three bytes of the address, which identify the vendor of the device.
+func (m *MacAddress) OUI() [3]byte {
+	return [3]byte{m[5], m[4], m[3]}
+}
+
 // IsNil checks if the MacAddress byte array is empty.
 func (m *MacAddress) IsNil() bool {
 	var numZeros int
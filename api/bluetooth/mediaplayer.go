@@ -1,5 +1,7 @@
 package bluetooth
 
+import ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
+
 // MediaPlayer describes a function call interface to invoke media player/control
 // related functions on a device.
 type MediaPlayer interface {
@@ -8,6 +10,10 @@ type MediaPlayer interface {
 
 	Properties() (MediaData, error)
 
+	// Features returns the media features supported by the device. If the device
+	// supports only basic media control, the media player feature is marked as degraded.
+	Features() (*ac.FeatureSet, error)
+
 	Play() error
 	Pause() error
 	TogglePlayPause() error
@@ -2,6 +2,8 @@ package bluetooth
 
 import (
 	"context"
+	"sync"
+	"time"
 )
 
 // Obex describes a function call interface to invoke Obex related functions
@@ -18,8 +20,9 @@ type ObexObjectPush interface {
 	// CreateSession creates a new Obex session with a device.
 	// The context (ctx) can be provided in case this function call
 	// needs to be cancelled, since this function call can take some time
-	// to complete.
-	CreateSession(ctx context.Context) error
+	// to complete. Options can be provided to customize the session,
+	// in which case only the first set of options is used.
+	CreateSession(ctx context.Context, options ...ObexSessionOptions) error
 
 	// RemoveSession removes a created Obex session.
 	RemoveSession() error
@@ -35,6 +38,75 @@ type ObexObjectPush interface {
 
 	// ResumeTransfer resumes the transfer.
 	ResumeTransfer() error
+
+	// Transfers returns the IDs of all the transfers that are queued or active
+	// within the session.
+	Transfers() ([]ObjectPushTransferID, error)
+
+	// SuspendTransferWithID suspends the transfer with the provided transfer ID,
+	// which can be obtained from the file transfer data returned by 'SendFile'.
+	SuspendTransferWithID(id ObjectPushTransferID) error
+
+	// ResumeTransferWithID resumes the transfer with the provided transfer ID,
+	// which can be obtained from the file transfer data returned by 'SendFile'.
+	ResumeTransferWithID(id ObjectPushTransferID) error
+
+	// CanResume returns whether the transfer with the provided transfer ID can be resumed,
+	// based on the current status of the transfer. If the ID is empty, the first transfer
+	// within the session is checked.
+	CanResume(id ObjectPushTransferID) (bool, error)
+}
+
+// ObexTarget describes an Obex service that a session can be created with.
+type ObexTarget string
+
+// The different Obex targets.
+const (
+	ObexTargetObjectPush   ObexTarget = "opp"
+	ObexTargetFileTransfer ObexTarget = "ftp"
+	ObexTargetPhonebook    ObexTarget = "pbap"
+	ObexTargetMessage      ObexTarget = "map"
+	ObexTargetSync         ObexTarget = "sync"
+)
+
+// ServiceClass returns the service class of the Obex target, which a device
+// advertises within its service UUIDs if it supports the target.
+func (o ObexTarget) ServiceClass() (uint32, bool) {
+	switch o {
+	case ObexTargetObjectPush:
+		return ObexObjpushServiceClass, true
+
+	case ObexTargetFileTransfer:
+		return ObexFiletransServiceClass, true
+
+	case ObexTargetPhonebook:
+		return PbapPseServiceClass, true
+
+	case ObexTargetMessage:
+		return MapMseServiceClass, true
+
+	case ObexTargetSync:
+		return IrmcSyncServiceClass, true
+	}
+
+	return 0, false
+}
+
+// ObexSessionOptions describes the options to create an Obex session with.
+// Options which are not applicable to a particular platform are ignored.
+type ObexSessionOptions struct {
+	// Target holds the Obex service to connect to, for example Object Push, File Transfer
+	// or Phonebook Access. If this is empty, the service of the interface that creates
+	// the session is used.
+	Target ObexTarget
+
+	// Source holds the address of the local adapter to create the session from.
+	// If this is empty, the adapter associated with the device is used.
+	Source MacAddress
+
+	// Channel holds the RFCOMM channel to connect to. If this is zero,
+	// the channel is discovered from the device's service records.
+	Channel byte
 }
 
 // ObjectPushStatus describes the status of the file transfer.
@@ -49,6 +121,13 @@ const (
 	TransferError     ObjectPushStatus = "error"
 )
 
+// Resumable returns whether a transfer with this status can be resumed.
+// Only suspended transfers can be resumed, since queued or active transfers
+// are already in progress, and complete or errored transfers have ended.
+func (o ObjectPushStatus) Resumable() bool {
+	return o == TransferSuspended
+}
+
 type (
 	objectPushID string
 
@@ -88,6 +167,10 @@ type ObjectPushData struct {
 
 // ObjectPushEventData holds the dynamic (variable) file transfer data for a device.
 // This is primarily used to send file transfer event related data.
+//
+// For both sending and receiving transfers, the address (DeviceAddress.Address) is always
+// the address of the peer (remote) device, and the associated adapter (DeviceAddress.AssociatedAdapter)
+// is always the address of the local adapter.
 type ObjectPushEventData struct {
 	DeviceAddress
 
@@ -105,6 +188,88 @@ type ObjectPushEventData struct {
 
 	// SessionID holds the ID of the session that this transferring item belongs to.
 	SessionID ObjectPushSessionID `json:"session_id,omitempty" codec:"Session,omitempty" doc:"The ID of the session that this transferring item belongs to."`
+
+	// Started holds the time at which the transfer became active.
+	Started time.Time `json:"started,omitzero" codec:"-" doc:"The time at which the transfer became active."`
+
+	// Completed holds the time at which the transfer was complete, or had errored.
+	Completed time.Time `json:"completed,omitzero" codec:"-" doc:"The time at which the transfer was complete, or had errored."`
+}
+
+// TransferStats holds the aggregate statistics of the file transfers with a device.
+type TransferStats struct {
+	// FilesSent holds the number of files that were sent to the device.
+	FilesSent uint64 `json:"files_sent" doc:"The number of files that were sent to the device."`
+
+	// FilesReceived holds the number of files that were received from the device.
+	FilesReceived uint64 `json:"files_received" doc:"The number of files that were received from the device."`
+
+	// BytesSent holds the number of bytes that were sent to the device.
+	BytesSent uint64 `json:"bytes_sent" doc:"The number of bytes that were sent to the device."`
+
+	// BytesReceived holds the number of bytes that were received from the device.
+	BytesReceived uint64 `json:"bytes_received" doc:"The number of bytes that were received from the device."`
+
+	// Failures holds the number of transfers that have errored, or were cancelled.
+	Failures uint64 `json:"failures" doc:"The number of transfers that have errored, or were cancelled."`
+}
+
+// ObjectPushTimer records the times at which transfers are started and completed.
+// Each transfer is tracked by its transfer ID.
+type ObjectPushTimer struct {
+	times map[ObjectPushTransferID]objectPushTimes
+
+	mu sync.Mutex
+}
+
+// objectPushTimes holds the recorded times of a transfer.
+type objectPushTimes struct {
+	started, completed time.Time
+}
+
+// NewObjectPushTimer returns a new transfer timer.
+func NewObjectPushTimer() *ObjectPushTimer {
+	return &ObjectPushTimer{times: make(map[ObjectPushTransferID]objectPushTimes)}
+}
+
+// Track records the start time of the transfer when its status is active, and its completion
+// time when its status is complete or error. The recorded times are then set on the transfer data.
+func (t *ObjectPushTimer) Track(data *ObjectPushEventData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	times := t.times[data.TransferID]
+
+	switch data.Status {
+	case TransferActive:
+		if times.started.IsZero() {
+			times.started = time.Now()
+		}
+
+	case TransferComplete, TransferError:
+		if times.completed.IsZero() {
+			times.completed = time.Now()
+		}
+
+		if times.started.IsZero() {
+			times.started = times.completed
+		}
+	}
+
+	t.times[data.TransferID] = times
+
+	data.Started, data.Completed = times.started, times.completed
+}
+
+// Remove sets the recorded times on the transfer data, and stops tracking the transfer.
+func (t *ObjectPushTimer) Remove(data *ObjectPushEventData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	times := t.times[data.TransferID]
+	delete(t.times, data.TransferID)
+
+	data.Started, data.Completed = times.started, times.completed
 }
 
 // AuthorizeReceiveFile describes an authentication interface, which is used
@@ -0,0 +1,94 @@
+package bluetooth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestObjectPushStatusResumable(t *testing.T) {
+	tests := []struct {
+		status ObjectPushStatus
+		want   bool
+	}{
+		{TransferQueued, false},
+		{TransferActive, false},
+		{TransferSuspended, true},
+		{TransferComplete, false},
+		{TransferError, false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.Resumable(); got != tt.want {
+				t.Errorf("Resumable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectPushTimer(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []ObjectPushStatus
+		startedAt   int
+		completedAt int
+	}{
+		{"queued", []ObjectPushStatus{TransferQueued}, -1, -1},
+		{"active", []ObjectPushStatus{TransferQueued, TransferActive}, 1, -1},
+		{"complete", []ObjectPushStatus{TransferQueued, TransferActive, TransferActive, TransferComplete}, 1, 3},
+		{"suspended and resumed", []ObjectPushStatus{TransferActive, TransferSuspended, TransferActive, TransferComplete}, 0, 3},
+		{"error", []ObjectPushStatus{TransferActive, TransferError}, 0, 1},
+		{"complete without being active", []ObjectPushStatus{TransferQueued, TransferComplete}, 1, 1},
+		{"updates after completion", []ObjectPushStatus{TransferActive, TransferComplete, TransferComplete}, 0, 1},
+	}
+
+	// within returns whether the time was recorded during the step at the index,
+	// or whether the time is not set if the index is negative.
+	within := func(recorded time.Time, marks []time.Time, index int) bool {
+		if index < 0 {
+			return recorded.IsZero()
+		}
+
+		return !recorded.Before(marks[index]) && !recorded.After(marks[index+1])
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timer := NewObjectPushTimer()
+			data := ObjectPushEventData{TransferID: "transfer0"}
+
+			marks := []time.Time{time.Now()}
+			for _, status := range tt.statuses {
+				time.Sleep(time.Millisecond)
+
+				data.Status = status
+				timer.Track(&data)
+
+				marks = append(marks, time.Now())
+			}
+
+			if !within(data.Started, marks, tt.startedAt) {
+				t.Errorf("started = %v, want the time of step %d", data.Started, tt.startedAt)
+			}
+			if !within(data.Completed, marks, tt.completedAt) {
+				t.Errorf("completed = %v, want the time of step %d", data.Completed, tt.completedAt)
+			}
+
+			removed := ObjectPushEventData{TransferID: data.TransferID}
+			timer.Remove(&removed)
+
+			if !removed.Started.Equal(data.Started) || !removed.Completed.Equal(data.Completed) {
+				t.Errorf("removed times = (%v, %v), want (%v, %v)", removed.Started, removed.Completed, data.Started, data.Completed)
+			}
+
+			// The transfer is no longer tracked once it is removed.
+			untracked := ObjectPushEventData{TransferID: data.TransferID, Status: TransferQueued}
+			timer.Track(&untracked)
+
+			if !untracked.Started.IsZero() || !untracked.Completed.IsZero() {
+				t.Errorf("times after removal = (%v, %v), want zero", untracked.Started, untracked.Completed)
+			}
+		})
+	}
+}
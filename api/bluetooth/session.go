@@ -1,9 +1,13 @@
 package bluetooth
 
 import (
+	"context"
+	"time"
+
 	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
 	"github.com/bluetuith-org/bluetooth-classic/api/config"
 	"github.com/bluetuith-org/bluetooth-classic/api/platforminfo"
+	"github.com/google/uuid"
 )
 
 // Session describes a Bluetooth application session.
@@ -25,6 +29,14 @@ type Session interface {
 	// Device returns a function call interface to invoke device related functions.
 	Device(address DeviceAddress) Device
 
+	// RefreshAdapter fetches the properties of the adapter from the system's Bluetooth daemon
+	// or service, updates the adapter within the session store, and publishes an adapter update event.
+	RefreshAdapter(address AdapterAddress) (AdapterData, error)
+
+	// RefreshDevice fetches the properties of the device from the system's Bluetooth daemon
+	// or service, updates the device within the session store, and publishes a device update event.
+	RefreshDevice(address DeviceAddress) (DeviceData, error)
+
 	// Obex returns a function call interface to invoke obex related functions.
 	Obex(address DeviceAddress) Obex
 
@@ -34,4 +46,71 @@ type Session interface {
 	// MediaPlayer returns a function call interface to invoke media player/control
 	// related functions on a device.
 	MediaPlayer(address DeviceAddress) MediaPlayer
+
+	// WatchServicesResolved waits for the services of the device to be resolved, and sends
+	// the device's service UUIDs once via the returned channel. If the services are already
+	// resolved, the UUIDs are sent immediately. The channel is closed once the UUIDs are sent,
+	// the context (ctx) is cancelled, or the returned function is called.
+	WatchServicesResolved(ctx context.Context, address DeviceAddress) (<-chan []uuid.UUID, func())
+
+	// WatchConnectedDevices sends the list of connected devices via the returned channel, first
+	// with the currently connected devices, and then each time a device connects or disconnects.
+	// The channel is closed once the context (ctx) is cancelled, or the returned function is called.
+	WatchConnectedDevices(ctx context.Context) (<-chan []DeviceData, func())
+
+	// WatchLinkQuality samples the link quality of the connected device (for example, its RSSI and
+	// transmit power) at each interval, and sends each sample via the returned channel. If no quality
+	// metrics are available for the device, [errorkinds.ErrNotSupported] is returned, which is common
+	// on Linux, since Bluez usually omits the RSSI of connected devices. The channel is closed once the
+	// device disconnects, the context (ctx) is cancelled, or the returned function is called. If the
+	// interval is zero or lesser, [DefaultLinkQualityInterval] is used.
+	WatchLinkQuality(ctx context.Context, address DeviceAddress, interval time.Duration) (<-chan LinkQuality, func(), error)
+
+	// SetLowPowerMode enables or disables the low-power mode. While the low-power mode is enabled, the
+	// periodic link quality sampling of all [Session.WatchLinkQuality] watchers is suspended, which is
+	// currently the only background polling that is performed by the session. Once the low-power mode
+	// is disabled, each watcher immediately takes a sample and resumes sampling. Events are neither
+	// coalesced nor delayed, and are still received and published as usual.
+	SetLowPowerMode(enable bool)
+
+	// LowPowerMode returns whether the low-power mode is enabled.
+	LowPowerMode() bool
+
+	// WatchRawEvents sends the raw event frames that are received from the server via the returned
+	// channel, alongside the regular typed events. Frames with unknown event IDs, and frames which could
+	// not be decoded, are sent as well. If the subscriber cannot keep up, raw events are dropped.
+	// The channel is closed once the context (ctx) is cancelled, or the returned function is called.
+	// This is only supported by sessions which communicate with a server, like 'haraltd'.
+	WatchRawEvents(ctx context.Context) (<-chan RawEvent, func(), error)
+
+	// RecentErrors returns the 'n' most recently published errors of the session, in the order in which
+	// they were published, so that errors can be queried without a subscriber having been attached to the
+	// error event stream when they occurred. If 'n' is zero or lesser, all the recorded errors are returned.
+	// The number of recorded errors is bounded by the configured error history capacity.
+	RecentErrors(n int) []BluetoothError
+
+	// TransferStats returns the aggregate statistics of the file transfers with the device,
+	// since the session was started or the statistics were last reset. If the device is not known,
+	// the returned error matches [errorkinds.ErrDeviceNotFound].
+	TransferStats(address DeviceAddress) (TransferStats, error)
+
+	// ResetTransferStats resets the file transfer statistics of the device. If the device
+	// is not known, the returned error matches [errorkinds.ErrDeviceNotFound].
+	ResetTransferStats(address DeviceAddress) error
+
+	// SupportsObexTarget returns whether the device advertises the service of the Obex target,
+	// so that an Obex session is not attempted with a device that does not support it.
+	SupportsObexTarget(address MacAddress, target ObexTarget) (bool, error)
+
+	// AddressFormatter returns the formatter that is used to display Bluetooth addresses.
+	// If no formatter was set, a formatter with the canonical address style is returned.
+	AddressFormatter() AddressFormatter
+
+	// SetAddressFormatter sets the formatter that is used to display Bluetooth addresses.
+	SetAddressFormatter(formatter AddressFormatter)
+
+	// ExperimentalFeatures returns the identifiers of the experimental features that are enabled
+	// within the Bluetooth daemon. If the daemon does not expose this information (for example,
+	// on older daemon versions), an empty list is returned.
+	ExperimentalFeatures() ([]string, error)
 }
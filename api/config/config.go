@@ -1,22 +1,64 @@
 package config
 
 import (
+	"context"
 	"time"
 )
 
 const (
 	// DefaultAuthTimeout is the default timeout duration for authentication requests.
 	DefaultAuthTimeout = 10 * time.Second
+
+	// DefaultOperationTimeout is the default timeout duration for long-running operations.
+	DefaultOperationTimeout = 60 * time.Second
+
+	// DefaultMaxEventSize is the default maximum size (in bytes) of a single event.
+	DefaultMaxEventSize = 4 * 1024 * 1024
+
+	// DefaultReconnectAttempts is the default number of attempts to reconnect to the 'haraltd' daemon.
+	// Reconnection is opt-in, so by default the session is stopped when the connection is lost.
+	DefaultReconnectAttempts = 0
+
+	// DefaultErrorHistoryCapacity is the default number of errors that are kept in the error history of a session.
+	DefaultErrorHistoryCapacity = 50
 )
 
 // Configuration describes a general configuration.
+//
+// For every numeric option, a zero value selects the default for that option, so that
+// a zero-valued configuration behaves the same as the one returned by [New]. Options that
+// can be disabled are disabled with a negative value.
 type Configuration struct {
 	// SocketPath holds the user-defined path to the socket used to interface with the 'haraltd' daemon.
 	SocketPath string
 
 	// AuthTimeout holds the timeout for authentication requests.
+	// If this is zero or negative, DefaultAuthTimeout is used.
 	AuthTimeout time.Duration
 
+	// OperationTimeout holds the timeout for long-running operations, like connecting to
+	// or pairing with a device, or creating an OBEX session. It is applied only if the
+	// operation is not provided a context with a deadline, since an explicit context deadline
+	// always takes precedence, and an operation whose context is already done fails immediately.
+	// If this is zero, DefaultOperationTimeout is used. If this is negative, operations
+	// will wait until they complete.
+	OperationTimeout time.Duration
+
+	// MaxEventSize holds the maximum size (in bytes) of a single event or response that
+	// is received from the 'haraltd' daemon. Events which exceed this size are discarded,
+	// and an error is published instead. If this is zero or negative, DefaultMaxEventSize is used.
+	MaxEventSize int
+
+	// ReconnectAttempts holds the number of attempts to reconnect to the 'haraltd' daemon if the
+	// connection is lost. Once reconnected, the session is resynchronized with the daemon's state.
+	// If this is zero, DefaultReconnectAttempts is used. Reconnection is disabled by default, and if
+	// this is zero or negative, the session is stopped when the connection is lost.
+	ReconnectAttempts int
+
+	// ErrorHistoryCapacity holds the maximum number of recently published errors that are kept
+	// in the error history of the session. If this is zero or negative, DefaultErrorHistoryCapacity is used.
+	ErrorHistoryCapacity int
+
 	// LibraryPath holds the custom user-defined path for the 'libhbluetooth' library.
 	LibraryPath string
 
@@ -26,9 +68,52 @@ type Configuration struct {
 	EnableObexServices bool
 }
 
-// New returns a new configuration with the default authentication timeout.
+// New returns a new configuration with the default authentication and operation timeouts,
+// the default maximum event size, the default number of reconnection attempts and the default
+// error history capacity.
 func New() Configuration {
 	return Configuration{
-		AuthTimeout: DefaultAuthTimeout,
+		AuthTimeout:          DefaultAuthTimeout,
+		OperationTimeout:     DefaultOperationTimeout,
+		MaxEventSize:         DefaultMaxEventSize,
+		ReconnectAttempts:    DefaultReconnectAttempts,
+		ErrorHistoryCapacity: DefaultErrorHistoryCapacity,
+	}
+}
+
+// WithDefaults returns a copy of the configuration, where the options that are set to
+// zero are replaced with their default values.
+func (c Configuration) WithDefaults() Configuration {
+	if c.AuthTimeout <= 0 {
+		c.AuthTimeout = DefaultAuthTimeout
+	}
+
+	if c.OperationTimeout == 0 {
+		c.OperationTimeout = DefaultOperationTimeout
+	}
+
+	if c.MaxEventSize <= 0 {
+		c.MaxEventSize = DefaultMaxEventSize
+	}
+
+	if c.ReconnectAttempts == 0 {
+		c.ReconnectAttempts = DefaultReconnectAttempts
+	}
+
+	if c.ErrorHistoryCapacity <= 0 {
+		c.ErrorHistoryCapacity = DefaultErrorHistoryCapacity
+	}
+
+	return c
+}
+
+// WithOperationTimeout returns a context derived from the parent context (ctx), which expires after
+// the provided timeout. If the parent context already has a deadline, or the timeout is zero or negative,
+// the parent context is returned with a no-op cancel function.
+func WithOperationTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
 	}
+
+	return context.WithTimeout(ctx, timeout)
 }
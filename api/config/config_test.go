@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Configuration
+		want Configuration
+	}{
+		{
+			name: "zero values use defaults",
+			cfg:  Configuration{},
+			want: New(),
+		},
+		{
+			name: "negative values disable",
+			cfg:  Configuration{OperationTimeout: -1, ReconnectAttempts: -1},
+			want: Configuration{
+				AuthTimeout:          DefaultAuthTimeout,
+				OperationTimeout:     -1,
+				MaxEventSize:         DefaultMaxEventSize,
+				ReconnectAttempts:    -1,
+				ErrorHistoryCapacity: DefaultErrorHistoryCapacity,
+			},
+		},
+		{
+			name: "negative values without a disabled state use defaults",
+			cfg:  Configuration{AuthTimeout: -1, MaxEventSize: -1, ErrorHistoryCapacity: -1},
+			want: New(),
+		},
+		{
+			name: "set values are kept",
+			cfg: Configuration{
+				AuthTimeout:          time.Second,
+				OperationTimeout:     2 * time.Second,
+				MaxEventSize:         1024,
+				ReconnectAttempts:    3,
+				ErrorHistoryCapacity: 10,
+			},
+			want: Configuration{
+				AuthTimeout:          time.Second,
+				OperationTimeout:     2 * time.Second,
+				MaxEventSize:         1024,
+				ReconnectAttempts:    3,
+				ErrorHistoryCapacity: 10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.WithDefaults(); got != tt.want {
+				t.Errorf("WithDefaults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
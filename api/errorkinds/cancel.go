@@ -0,0 +1,74 @@
+package errorkinds
+
+import (
+	"context"
+	"errors"
+)
+
+// CancelReason describes the reason an operation was cancelled.
+type CancelReason string
+
+// The different cancellation reasons.
+const (
+	CancelReasonContext          CancelReason = "context-cancelled"
+	CancelReasonSessionStopped   CancelReason = "session-stopped"
+	CancelReasonUserAborted      CancelReason = "user-aborted"
+	CancelReasonPeerDisconnected CancelReason = "peer-disconnected"
+)
+
+// CancelError describes an error of a cancelled operation, along with the reason
+// it was cancelled. It matches [ErrMethodCanceled], as well as the underlying error
+// which caused the cancellation, via [errors.Is].
+type CancelError struct {
+	// Reason holds the reason the operation was cancelled.
+	Reason CancelReason `json:"reason,omitempty" doc:"The reason the operation was cancelled."`
+
+	// Err holds the underlying error which caused the cancellation.
+	Err error `json:"-"`
+}
+
+// NewCancelError returns a new error for an operation which was cancelled
+// for the provided reason.
+func NewCancelError(reason CancelReason, err error) error {
+	return &CancelError{Reason: reason, Err: err}
+}
+
+// ContextError returns an error describing why the context (ctx) is done.
+// If the context's deadline was exceeded, [ErrMethodTimeout] is returned. Otherwise,
+// a [CancelError] with the [CancelReasonContext] reason is returned.
+func ContextError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrMethodTimeout
+	}
+
+	return NewCancelError(CancelReasonContext, ctx.Err())
+}
+
+// CancelReasonOf returns the reason an operation was cancelled, if the error
+// (or any error that it wraps) is a [CancelError].
+func CancelReasonOf(err error) (CancelReason, bool) {
+	var cancelErr *CancelError
+	if !errors.As(err, &cancelErr) {
+		return "", false
+	}
+
+	return cancelErr.Reason, true
+}
+
+// Error returns the formatted error as string.
+func (e *CancelError) Error() string {
+	if e.Err == nil {
+		return ErrMethodCanceled.Error() + " (" + string(e.Reason) + ")"
+	}
+
+	return ErrMethodCanceled.Error() + " (" + string(e.Reason) + "): " + e.Err.Error()
+}
+
+// Unwrap unwraps all errors associated with this error.
+func (e *CancelError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrMethodCanceled}
+	}
+
+	return []error{ErrMethodCanceled, e.Err}
+}
@@ -0,0 +1,103 @@
+package errorkinds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCancelError(t *testing.T) {
+	cause := errors.New("cause")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantReason CancelReason
+		wantCancel bool
+		wantCause  bool
+		wantString string
+	}{
+		{
+			name:       "with cause",
+			err:        NewCancelError(CancelReasonUserAborted, cause),
+			wantReason: CancelReasonUserAborted,
+			wantCancel: true,
+			wantCause:  true,
+			wantString: "method call was cancelled (user-aborted): cause",
+		},
+		{
+			name:       "without cause",
+			err:        NewCancelError(CancelReasonSessionStopped, nil),
+			wantReason: CancelReasonSessionStopped,
+			wantCancel: true,
+			wantString: "method call was cancelled (session-stopped)",
+		},
+		{
+			name:       "wrapped",
+			err:        fmt.Errorf("pair: %w", NewCancelError(CancelReasonPeerDisconnected, cause)),
+			wantReason: CancelReasonPeerDisconnected,
+			wantCancel: true,
+			wantCause:  true,
+			wantString: "pair: method call was cancelled (peer-disconnected): cause",
+		},
+		{
+			name:       "not cancelled",
+			err:        cause,
+			wantCause:  true,
+			wantString: "cause",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := CancelReasonOf(tt.err)
+			if reason != tt.wantReason || ok != tt.wantCancel {
+				t.Errorf("CancelReasonOf() = (%q, %v), want (%q, %v)", reason, ok, tt.wantReason, tt.wantCancel)
+			}
+			if got := errors.Is(tt.err, ErrMethodCanceled); got != tt.wantCancel {
+				t.Errorf("errors.Is(ErrMethodCanceled) = %v, want %v", got, tt.wantCancel)
+			}
+			if got := errors.Is(tt.err, cause); got != tt.wantCause {
+				t.Errorf("errors.Is(cause) = %v, want %v", got, tt.wantCause)
+			}
+			if got := tt.err.Error(); got != tt.wantString {
+				t.Errorf("Error() = %q, want %q", got, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestContextError(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantReason CancelReason
+		wantCancel bool
+		wantErr    error
+	}{
+		{"cancelled", cancelled, CancelReasonContext, true, context.Canceled},
+		{"deadline exceeded", expired, "", false, ErrMethodTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ContextError(tt.ctx)
+
+			reason, ok := CancelReasonOf(err)
+			if reason != tt.wantReason || ok != tt.wantCancel {
+				t.Errorf("CancelReasonOf() = (%q, %v), want (%q, %v)", reason, ok, tt.wantReason, tt.wantCancel)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ContextError() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
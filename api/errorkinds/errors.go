@@ -25,6 +25,16 @@ var (
 
 	ErrPropertyDataParse = errors.New("error parsing property data")
 	ErrEventDataParse    = errors.New("error parsing event data")
+	ErrEventTooLarge     = errors.New("event exceeds the maximum event size")
+
+	ErrDevicePairing         = errors.New("device could not be paired")
+	ErrDeviceServicesResolve = errors.New("device services could not be resolved")
+	ErrDeviceConnecting      = errors.New("device could not be connected")
+	ErrDeviceNotConnected    = errors.New("device is not connected")
+	ErrPairingInProgress     = errors.New("another pairing attempt is in progress")
+
+	ErrPropertyNotFound = errors.New("property not found")
+	ErrPropertyReadOnly = errors.New("property is read-only")
 
 	ErrNotSupported = errors.New("this functionality is not supported")
 )
@@ -0,0 +1,63 @@
+package authaudit
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+// FileAuditor describes an auditor which appends authorization decisions
+// to a file, with each decision being stored as a JSON object on a separate line.
+type FileAuditor struct {
+	file    *os.File
+	encoder *json.Encoder
+
+	mu sync.Mutex
+}
+
+// NewFileAuditor returns a new auditor, which appends authorization decisions to the file
+// at the provided path. The file is created if it does not exist.
+func NewFileAuditor(path string) (*FileAuditor, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileAuditor{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// AuditAuthorization appends the authorization decision to the file.
+// Any errors are published to the global error event stream.
+func (f *FileAuditor) AuditAuthorization(record bluetooth.AuthAuditRecord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return
+	}
+
+	if err := f.encoder.Encode(record); err != nil {
+		bluetooth.ErrorEvents().PublishAdded(errorkinds.GenericError{Errors: err})
+	}
+}
+
+// Close closes the file.
+func (f *FileAuditor) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
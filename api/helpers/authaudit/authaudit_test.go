@@ -0,0 +1,88 @@
+package authaudit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+)
+
+func TestFileAuditor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	auditor, err := NewFileAuditor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizer := bluetooth.NewAuditedAuthorizer(nil, auditor)
+	timeout := bluetooth.NewAuthTimeout(time.Millisecond)
+	<-timeout.Done()
+
+	decisions := []struct {
+		name    string
+		timeout bluetooth.AuthTimeout
+		err     error
+	}{
+		{"accept", bluetooth.NewAuthTimeout(time.Second), nil},
+		{"reject", bluetooth.NewAuthTimeout(time.Second), errors.New("rejected")},
+		{"timeout", timeout, timeout.Err()},
+	}
+
+	for _, decision := range decisions {
+		bluetooth.AuditAuthorization(authorizer, decision.timeout, bluetooth.AuthAuditRecord{Request: bluetooth.AuthRequestConfirmPasskey, Passkey: 123456}, decision.err)
+	}
+
+	if err := auditor.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Decisions which are audited after the auditor is closed are discarded.
+	bluetooth.AuditAuthorization(authorizer, timeout, bluetooth.AuthAuditRecord{}, nil)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var records []bluetooth.AuthAuditRecord
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record bluetooth.AuthAuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("cannot decode record %q: %v", scanner.Text(), err)
+		}
+
+		records = append(records, record)
+	}
+
+	if len(records) != len(decisions) {
+		t.Fatalf("audit file has %d records, want %d", len(records), len(decisions))
+	}
+
+	for i, decision := range decisions {
+		t.Run(decision.name, func(t *testing.T) {
+			record := records[i]
+
+			if record.Request != bluetooth.AuthRequestConfirmPasskey || record.Passkey != 123456 {
+				t.Errorf("record = %+v, want the request details", record)
+			}
+			if record.Authorized != (decision.err == nil) {
+				t.Errorf("record authorized = %v, want %v", record.Authorized, decision.err == nil)
+			}
+			if record.TimedOut != (decision.name == "timeout") {
+				t.Errorf("record timed out = %v, want %v", record.TimedOut, decision.name == "timeout")
+			}
+			if decision.err != nil && record.Error != decision.err.Error() {
+				t.Errorf("record error = %q, want %q", record.Error, decision.err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+/*
+Package authaudit provides auditors to record authorization decisions made during a session.
+*/
+package authaudit
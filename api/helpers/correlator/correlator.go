@@ -0,0 +1,121 @@
+package correlator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+// Correlator correlates requests which are published to an asynchronous event stream,
+// with their responses, using a unique request ID.
+type Correlator[K comparable, V any] struct {
+	pending map[K]chan V
+
+	mu sync.Mutex
+}
+
+// NewCorrelator returns a new correlator.
+func NewCorrelator[K comparable, V any]() *Correlator[K, V] {
+	return &Correlator[K, V]{pending: make(map[K]chan V)}
+}
+
+// Request registers a pending request with the provided ID, publishes the request using
+// the send function, and waits for the correlated response.
+//
+// If the timeout elapses before a response is received, [errorkinds.ErrMethodTimeout] is returned.
+// If the context (ctx) is cancelled, the context's error is returned. If the pending request is
+// cancelled, for example via [Correlator.CancelAll], [errorkinds.ErrMethodCanceled] is returned.
+// If the timeout is zero or lesser, the request waits until a response is received, or until
+// the context is cancelled. If the context is already done, the request is not published.
+func (c *Correlator[K, V]) Request(ctx context.Context, id K, timeout time.Duration, send func() error) (V, error) {
+	var response V
+
+	if err := ctx.Err(); err != nil {
+		return response, err
+	}
+
+	ch := make(chan V, 1)
+
+	c.mu.Lock()
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	defer c.remove(id, ch)
+
+	if err := send(); err != nil {
+		return response, err
+	}
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		expired = timer.C
+	}
+
+	select {
+	case <-ctx.Done():
+		return response, ctx.Err()
+
+	case <-expired:
+		return response, errorkinds.ErrMethodTimeout
+
+	case r, ok := <-ch:
+		if !ok {
+			return response, errorkinds.ErrMethodCanceled
+		}
+
+		return r, nil
+	}
+}
+
+// Resolve sends the response to the pending request with the provided ID.
+// If no pending request matches the ID, for example if the request has already
+// timed out, the response is discarded and false is returned.
+func (c *Correlator[K, V]) Resolve(id K, response V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, ok := c.pending[id]
+	if !ok {
+		return false
+	}
+
+	delete(c.pending, id)
+	ch <- response
+
+	return true
+}
+
+// CancelAll cancels all pending requests.
+func (c *Correlator[K, V]) CancelAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, ch := range c.pending {
+		delete(c.pending, id)
+		close(ch)
+	}
+}
+
+// Pending returns the number of pending requests.
+func (c *Correlator[K, V]) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.pending)
+}
+
+// remove removes the pending request with the provided ID, if it
+// has not been resolved or replaced by another request.
+func (c *Correlator[K, V]) remove(id K, ch chan V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if pending, ok := c.pending[id]; ok && pending == ch {
+		delete(c.pending, id)
+	}
+}
@@ -0,0 +1,134 @@
+package correlator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+func TestCorrelatorRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		ctx      func() (context.Context, context.CancelFunc)
+		respond  func(c *Correlator[int, string], id int)
+		wantSent bool
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "matched",
+			timeout:  time.Second,
+			respond:  func(c *Correlator[int, string], id int) { c.Resolve(id, "reply") },
+			wantSent: true,
+			want:     "reply",
+		},
+		{
+			name:     "matched without timeout",
+			respond:  func(c *Correlator[int, string], id int) { c.Resolve(id, "reply") },
+			wantSent: true,
+			want:     "reply",
+		},
+		{
+			name:     "unmatched times out",
+			timeout:  50 * time.Millisecond,
+			respond:  func(c *Correlator[int, string], id int) { c.Resolve(id+1, "other") },
+			wantSent: true,
+			wantErr:  errorkinds.ErrMethodTimeout,
+		},
+		{
+			name:     "timed out",
+			timeout:  50 * time.Millisecond,
+			wantSent: true,
+			wantErr:  errorkinds.ErrMethodTimeout,
+		},
+		{
+			name:    "context cancelled",
+			timeout: time.Second,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			wantSent: true,
+			wantErr:  context.DeadlineExceeded,
+		},
+		{
+			name:    "context already done",
+			timeout: time.Second,
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx, cancel
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name:     "cancelled",
+			timeout:  time.Second,
+			respond:  func(c *Correlator[int, string], _ int) { c.CancelAll() },
+			wantSent: true,
+			wantErr:  errorkinds.ErrMethodCanceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const id = 1
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.ctx != nil {
+				ctx, cancel = tt.ctx()
+			}
+			defer cancel()
+
+			c := NewCorrelator[int, string]()
+
+			var sent bool
+			got, err := c.Request(ctx, id, tt.timeout, func() error {
+				sent = true
+				if tt.respond != nil {
+					go tt.respond(c, id)
+				}
+
+				return nil
+			})
+
+			if sent != tt.wantSent {
+				t.Errorf("request sent = %v, want %v", sent, tt.wantSent)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Request() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Request() = %q, want %q", got, tt.want)
+			}
+			if pending := c.Pending(); pending != 0 {
+				t.Errorf("Pending() = %d, want 0", pending)
+			}
+		})
+	}
+}
+
+func TestCorrelatorSendError(t *testing.T) {
+	sendErr := errors.New("send failed")
+
+	c := NewCorrelator[int, string]()
+	if _, err := c.Request(context.Background(), 1, time.Second, func() error { return sendErr }); !errors.Is(err, sendErr) {
+		t.Fatalf("Request() error = %v, want %v", err, sendErr)
+	}
+
+	if pending := c.Pending(); pending != 0 {
+		t.Errorf("Pending() = %d, want 0", pending)
+	}
+}
+
+func TestCorrelatorResolveUnmatched(t *testing.T) {
+	c := NewCorrelator[int, string]()
+
+	if c.Resolve(1, "reply") {
+		t.Error("Resolve() matched a request that was never sent")
+	}
+}
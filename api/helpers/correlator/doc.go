@@ -0,0 +1,5 @@
+/*
+Package correlator provides a helper to correlate requests which are sent over an
+asynchronous event stream with their responses.
+*/
+package correlator
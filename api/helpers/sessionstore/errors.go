@@ -0,0 +1,29 @@
+package sessionstore
+
+import (
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/config"
+)
+
+// ErrorEvents returns an event interface to publish and subscribe to error events.
+// Errors which are published via the returned event interface are recorded
+// within the error history of the store.
+func (s *SessionStore) ErrorEvents() bluetooth.ErrorEventGroup {
+	return s.errors.ErrorEvents()
+}
+
+// RecentErrors returns the 'n' most recently published errors of the store, in the order in which
+// they were published. If 'n' is zero or lesser, all the errors within the error history are returned.
+func (s *SessionStore) RecentErrors(n int) []bluetooth.BluetoothError {
+	return s.errors.RecentErrors(n)
+}
+
+// SetErrorHistoryCapacity sets the maximum number of errors that are kept in the error history
+// of the store. If the capacity is zero or lesser, [config.DefaultErrorHistoryCapacity] is used.
+func (s *SessionStore) SetErrorHistoryCapacity(capacity int) {
+	if capacity <= 0 {
+		capacity = config.DefaultErrorHistoryCapacity
+	}
+
+	s.errors.SetCapacity(capacity)
+}
@@ -0,0 +1,128 @@
+package sessionstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+// WatchLinkQuality samples the link quality of the device using the provided sample function, first
+// immediately and then at each interval, and sends each sample via the returned channel. If the receiver
+// has not read a previously sent sample, it is replaced with the latest sample. If the interval is zero
+// or lesser, [bluetooth.DefaultLinkQualityInterval] is used.
+//
+// While the store is in low-power mode, no samples are taken. Once the low-power mode is disabled,
+// a sample is taken immediately, and sampling resumes at each interval.
+//
+// If the device is not connected, [errorkinds.ErrDeviceNotConnected] is returned, and if the first sample
+// does not hold any quality metrics, [errorkinds.ErrNotSupported] is returned.
+//
+// The channel is closed once the device disconnects or is removed, the context (ctx) is cancelled,
+// or the returned function is called.
+func (s *SessionStore) WatchLinkQuality(
+	ctx context.Context,
+	address bluetooth.DeviceAddress,
+	interval time.Duration,
+	sample func() (bluetooth.LinkQuality, error),
+) (<-chan bluetooth.LinkQuality, func(), error) {
+	if interval <= 0 {
+		interval = bluetooth.DefaultLinkQualityInterval
+	}
+
+	device, err := s.Device(address)
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	if !device.Connected.Value() {
+		return nil, func() {}, fmt.Errorf("link quality %q: %w", address.Address.String(), errorkinds.ErrDeviceNotConnected)
+	}
+
+	first, err := sample()
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	if !first.HasMetrics() {
+		return nil, func() {}, fmt.Errorf("link quality %q: %w", address.Address.String(), errorkinds.ErrNotSupported)
+	}
+
+	samples := make(chan bluetooth.LinkQuality, 1)
+	ctx, cancel := context.WithCancel(ctx)
+
+	sub, ok := bluetooth.DeviceEvents().Subscribe()
+	if !ok {
+		close(samples)
+		return samples, cancel, nil
+	}
+
+	samples <- first
+
+	go func() {
+		defer close(samples)
+		defer sub.Unsubscribe()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		send := func() {
+			quality, err := sample()
+			if err != nil {
+				return
+			}
+
+			select {
+			case <-samples:
+			default:
+			}
+
+			samples <- quality
+		}
+
+		for {
+			// The ticker is stopped while the store is in low-power mode, so that no
+			// samples are taken until the low-power mode is disabled.
+			lowPower, changed := s.lowPowerMode()
+
+			var tick <-chan time.Time
+			if lowPower {
+				ticker.Stop()
+			} else {
+				tick = ticker.C
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-changed:
+				if lowPower {
+					ticker.Reset(interval)
+					send()
+				}
+
+			case device, ok := <-sub.UpdatedEvents:
+				if !ok {
+					return
+				}
+
+				if connected, ok := device.Connected.Get(); ok && !connected && device.DeviceAddress == address {
+					return
+				}
+
+			case device, ok := <-sub.RemovedEvents:
+				if !ok || device.DeviceAddress == address {
+					return
+				}
+
+			case <-tick:
+				send()
+			}
+		}
+	}()
+
+	return samples, cancel, nil
+}
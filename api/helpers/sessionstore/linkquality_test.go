@@ -0,0 +1,208 @@
+package sessionstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+)
+
+func TestWatchLinkQuality(t *testing.T) {
+	mac, err := bluetooth.ParseMAC("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := bluetooth.NewDeviceAddress(mac, mac)
+	errSample := errors.New("sample failed")
+
+	tests := []struct {
+		name      string
+		add       bool
+		connected bool
+		quality   bluetooth.LinkQuality
+		sampleErr error
+		wantErr   error
+	}{
+		{name: "samples", add: true, connected: true, quality: bluetooth.LinkQuality{RSSI: optional.New[int16](-40)}},
+		{name: "unknown device", wantErr: errorkinds.ErrDeviceNotFound},
+		{name: "not connected", add: true, quality: bluetooth.LinkQuality{RSSI: optional.New[int16](-40)}, wantErr: errorkinds.ErrDeviceNotConnected},
+		{name: "no metrics", add: true, connected: true, wantErr: errorkinds.ErrNotSupported},
+		{name: "sample error", add: true, connected: true, sampleErr: errSample, wantErr: errSample},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+			if tt.add {
+				store.AddDevice(bluetooth.DeviceData{
+					DeviceEventData: bluetooth.DeviceEventData{
+						DeviceAddress: address,
+						Connected:     optional.New(tt.connected),
+					},
+				})
+			}
+
+			sample := func() (bluetooth.LinkQuality, error) {
+				quality := tt.quality
+				quality.Time = time.Now()
+
+				return quality, tt.sampleErr
+			}
+
+			samples, stop, err := store.WatchLinkQuality(context.Background(), address, 10*time.Millisecond, sample)
+			defer stop()
+
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("WatchLinkQuality() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			// The first sample is taken immediately, and the subsequent samples at each interval.
+			for range 3 {
+				select {
+				case quality, ok := <-samples:
+					if !ok {
+						t.Fatal("samples channel was closed")
+					}
+					if quality.RSSI != tt.quality.RSSI {
+						t.Errorf("sample RSSI = %v, want %v", quality.RSSI, tt.quality.RSSI)
+					}
+
+				case <-time.After(time.Second):
+					t.Fatal("no sample was received")
+				}
+			}
+		})
+	}
+}
+
+func TestWatchLinkQualityStops(t *testing.T) {
+	mac, err := bluetooth.ParseMAC("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherMac, err := bluetooth.ParseMAC("66:77:88:99:AA:BB")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := bluetooth.NewDeviceAddress(mac, mac)
+	other := bluetooth.NewDeviceAddress(otherMac, mac)
+
+	tests := []struct {
+		name     string
+		stop     func(stop func(), cancel context.CancelFunc) func()
+		wantOpen bool
+	}{
+		{
+			name: "device disconnected",
+			stop: func(func(), context.CancelFunc) func() {
+				return func() {
+					bluetooth.DeviceEvents().PublishUpdated(bluetooth.DeviceEventData{DeviceAddress: address, Connected: optional.New(false)})
+				}
+			},
+		},
+		{
+			name: "device removed",
+			stop: func(func(), context.CancelFunc) func() {
+				return func() {
+					bluetooth.DeviceEvents().PublishRemoved(bluetooth.DeviceEventData{DeviceAddress: address})
+				}
+			},
+		},
+		{
+			name: "context cancelled",
+			stop: func(_ func(), cancel context.CancelFunc) func() { return cancel },
+		},
+		{
+			name: "stop function called",
+			stop: func(stop func(), _ context.CancelFunc) func() { return stop },
+		},
+		{
+			name: "other device disconnected",
+			stop: func(func(), context.CancelFunc) func() {
+				return func() {
+					bluetooth.DeviceEvents().PublishUpdated(bluetooth.DeviceEventData{DeviceAddress: other, Connected: optional.New(false)})
+				}
+			},
+			wantOpen: true,
+		},
+		{
+			name: "device updated without disconnecting",
+			stop: func(func(), context.CancelFunc) func() {
+				return func() {
+					bluetooth.DeviceEvents().PublishUpdated(bluetooth.DeviceEventData{DeviceAddress: address, Name: optional.New("renamed")})
+				}
+			},
+			wantOpen: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+			store.AddDevice(bluetooth.DeviceData{
+				DeviceEventData: bluetooth.DeviceEventData{
+					DeviceAddress: address,
+					Connected:     optional.New(true),
+				},
+			})
+
+			sample := func() (bluetooth.LinkQuality, error) {
+				return bluetooth.LinkQuality{Time: time.Now(), RSSI: optional.New[int16](-40)}, nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// The interval is longer than the test, so that only the first sample is sent.
+			samples, stop, err := store.WatchLinkQuality(ctx, address, time.Hour, sample)
+			if err != nil {
+				t.Fatalf("WatchLinkQuality() error = %v", err)
+			}
+			defer stop()
+
+			if _, ok := <-samples; !ok {
+				t.Fatal("samples channel was closed before the first sample")
+			}
+
+			// The events are published repeatedly, since events which are not
+			// received immediately by the watcher are dropped.
+			trigger := tt.stop(stop, cancel)
+			deadline := time.After(200 * time.Millisecond)
+
+			for {
+				trigger()
+
+				select {
+				case _, ok := <-samples:
+					if ok {
+						t.Fatal("a sample was received after the first sample")
+					}
+					if tt.wantOpen {
+						t.Fatal("samples channel was closed, want it to stay open")
+					}
+
+					return
+
+				case <-deadline:
+					if !tt.wantOpen {
+						t.Fatal("samples channel was not closed")
+					}
+
+					return
+
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+		})
+	}
+}
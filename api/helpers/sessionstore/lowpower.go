@@ -0,0 +1,47 @@
+package sessionstore
+
+import "sync"
+
+// lowPowerState holds the low-power mode of the store.
+type lowPowerState struct {
+	enabled bool
+
+	// changed is closed and replaced each time the low-power mode changes,
+	// so that background activities can suspend or resume themselves.
+	changed chan struct{}
+
+	mu sync.Mutex
+}
+
+// SetLowPowerMode enables or disables the low-power mode. While the low-power mode is enabled,
+// the link quality sampling of all [SessionStore.WatchLinkQuality] watchers is suspended. Once
+// the low-power mode is disabled, each watcher immediately takes a sample and resumes sampling.
+func (s *SessionStore) SetLowPowerMode(enable bool) {
+	s.lowPower.mu.Lock()
+	defer s.lowPower.mu.Unlock()
+
+	if s.lowPower.enabled == enable {
+		return
+	}
+
+	s.lowPower.enabled = enable
+
+	close(s.lowPower.changed)
+	s.lowPower.changed = make(chan struct{})
+}
+
+// LowPowerMode returns whether the low-power mode is enabled.
+func (s *SessionStore) LowPowerMode() bool {
+	enabled, _ := s.lowPowerMode()
+
+	return enabled
+}
+
+// lowPowerMode returns whether the low-power mode is enabled, along with
+// a channel which is closed once the low-power mode changes.
+func (s *SessionStore) lowPowerMode() (bool, <-chan struct{}) {
+	s.lowPower.mu.Lock()
+	defer s.lowPower.mu.Unlock()
+
+	return s.lowPower.enabled, s.lowPower.changed
+}
@@ -0,0 +1,94 @@
+package sessionstore
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+)
+
+func TestLowPowerModeSuspendsLinkQuality(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+	}{
+		{"short interval", 10 * time.Millisecond},
+		{"interval longer than the test", time.Hour},
+	}
+
+	mac, err := bluetooth.ParseMAC("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := bluetooth.NewDeviceAddress(mac, mac)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+			store.AddDevice(bluetooth.DeviceData{
+				DeviceEventData: bluetooth.DeviceEventData{
+					DeviceAddress: address,
+					Connected:     optional.New(true),
+				},
+			})
+
+			var sampled atomic.Int64
+			sample := func() (bluetooth.LinkQuality, error) {
+				sampled.Add(1)
+
+				return bluetooth.LinkQuality{Time: time.Now(), RSSI: optional.New[int16](-40)}, nil
+			}
+
+			samples, stop, err := store.WatchLinkQuality(context.Background(), address, tt.interval, sample)
+			if err != nil {
+				t.Fatalf("WatchLinkQuality() error = %v", err)
+			}
+			defer stop()
+
+			receive := func() {
+				t.Helper()
+
+				select {
+				case _, ok := <-samples:
+					if !ok {
+						t.Fatal("samples channel was closed")
+					}
+
+				case <-time.After(time.Second):
+					t.Fatal("no sample was received")
+				}
+			}
+
+			receive()
+
+			store.SetLowPowerMode(true)
+			if !store.LowPowerMode() {
+				t.Fatal("LowPowerMode() = false, want true")
+			}
+
+			// Wait for any sample which was in progress when the low-power mode was enabled.
+			time.Sleep(min(5*tt.interval, 50*time.Millisecond))
+			for len(samples) > 0 {
+				<-samples
+			}
+
+			suspended := sampled.Load()
+			time.Sleep(100 * time.Millisecond)
+
+			if got := sampled.Load(); got != suspended {
+				t.Fatalf("%d samples were taken in low-power mode", got-suspended)
+			}
+
+			store.SetLowPowerMode(false)
+			receive()
+
+			if got := sampled.Load(); got <= suspended {
+				t.Fatalf("no sample was taken after the low-power mode was disabled")
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package sessionstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+// PairAndConnect pairs with the device using the pair function, waits for the services of the device
+// to be resolved, and then connects to the device using the connect function. Steps which are already
+// complete within the store are skipped. If the device is already paired, the services are not waited
+// for, since they may only be resolved once the device is connected.
+//
+// The pair and connect functions are provided the context (ctx), and must stop the pairing or connection
+// attempt once it is done. The returned error matches [errorkinds.ErrDevicePairing],
+// [errorkinds.ErrDeviceServicesResolve] or [errorkinds.ErrDeviceConnecting], depending on the step that failed.
+func (s *SessionStore) PairAndConnect(
+	ctx context.Context,
+	address bluetooth.DeviceAddress,
+	pair, connect func(ctx context.Context) error,
+) error {
+	data, err := s.Device(address)
+	if err != nil {
+		return err
+	}
+
+	if !data.Paired.Value() {
+		if err := pair(ctx); err != nil {
+			return fmt.Errorf("pair %q: %w: %w", address.Address.String(), errorkinds.ErrDevicePairing, err)
+		}
+
+		if err := s.waitForDevice(ctx, address, servicesResolved); err != nil {
+			return fmt.Errorf("resolve services %q: %w: %w", address.Address.String(), errorkinds.ErrDeviceServicesResolve, err)
+		}
+	}
+
+	if data, err := s.Device(address); err == nil && data.Connected.Value() {
+		return nil
+	}
+
+	if err := connect(ctx); err != nil {
+		return fmt.Errorf("connect %q: %w: %w", address.Address.String(), errorkinds.ErrDeviceConnecting, err)
+	}
+
+	return nil
+}
+
+// waitForDevice waits until the condition (cond) is satisfied for the device.
+// The condition is first checked against the device data within the store, and then against each
+// subsequent device update.
+func (s *SessionStore) waitForDevice(
+	ctx context.Context,
+	address bluetooth.DeviceAddress,
+	cond func(device bluetooth.DeviceEventData) bool,
+) error {
+	// Subscribe before checking the store, so that an update which
+	// arrives in between is not missed.
+	sub, ok := bluetooth.DeviceEvents().Subscribe()
+	if !ok {
+		return errorkinds.ErrMethodCall
+	}
+	defer sub.Unsubscribe()
+
+	device, err := s.Device(address)
+	if err != nil {
+		return err
+	}
+
+	if cond(device.DeviceEventData) {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errorkinds.ContextError(ctx)
+
+		case device, ok := <-sub.UpdatedEvents:
+			if !ok {
+				return errorkinds.NewCancelError(errorkinds.CancelReasonSessionStopped, nil)
+			}
+
+			if device.DeviceAddress == address && cond(device) {
+				return nil
+			}
+		}
+	}
+}
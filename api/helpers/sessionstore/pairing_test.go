@@ -0,0 +1,121 @@
+package sessionstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+	"github.com/google/uuid"
+)
+
+func TestPairAndConnect(t *testing.T) {
+	uuids := uuid.UUIDs{uuid.MustParse("0000110b-0000-1000-8000-00805f9b34fb")}
+
+	tests := []struct {
+		name        string
+		device      bluetooth.DeviceEventData
+		pairErr     error
+		connectErr  error
+		wantPair    bool
+		wantConnect bool
+		wantErr     error
+	}{
+		{
+			name:        "pair and connect",
+			wantPair:    true,
+			wantConnect: true,
+		},
+		{
+			name:     "pair failed",
+			pairErr:  errors.New("authentication rejected"),
+			wantPair: true,
+			wantErr:  errorkinds.ErrDevicePairing,
+		},
+		{
+			name:        "pair succeeded and connect failed",
+			connectErr:  errors.New("page timeout"),
+			wantPair:    true,
+			wantConnect: true,
+			wantErr:     errorkinds.ErrDeviceConnecting,
+		},
+		{
+			name:        "already paired and disconnected",
+			device:      bluetooth.DeviceEventData{Paired: optional.New(true), ServicesResolved: optional.New(false), UUIDs: uuids},
+			wantConnect: true,
+		},
+		{
+			name:        "already paired without known services",
+			device:      bluetooth.DeviceEventData{Paired: optional.New(true), ServicesResolved: optional.New(false)},
+			wantConnect: true,
+		},
+		{
+			name:   "already paired and connected",
+			device: bluetooth.DeviceEventData{Paired: optional.New(true), Connected: optional.New(true), ServicesResolved: optional.New(true), UUIDs: uuids},
+		},
+	}
+
+	address := bluetooth.NewDeviceAddress(bluetooth.MacAddress{1, 2, 3, 4, 5, 6}, bluetooth.MacAddress{0, 0, 0, 0, 0, 1})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+
+			tt.device.DeviceAddress = address
+			store.AddDevice(bluetooth.DeviceData{DeviceEventData: tt.device})
+
+			var paired, connected bool
+
+			pair := func(context.Context) error {
+				paired = true
+				if tt.pairErr != nil {
+					return tt.pairErr
+				}
+
+				update := func(mergefn MergeDeviceDataFunc) {
+					if device, err := store.UpdateDevice(address, mergefn); err == nil {
+						bluetooth.DeviceEvents().PublishUpdated(device)
+					}
+				}
+
+				update(func(device *bluetooth.DeviceData) error {
+					device.Paired = optional.New(true)
+					return nil
+				})
+
+				// The services are resolved only after the pairing is complete.
+				time.AfterFunc(50*time.Millisecond, func() {
+					update(func(device *bluetooth.DeviceData) error {
+						device.ServicesResolved = optional.New(true)
+						device.UUIDs = uuids
+
+						return nil
+					})
+				})
+
+				return nil
+			}
+
+			connect := func(context.Context) error {
+				connected = true
+				return tt.connectErr
+			}
+
+			// The timeout is only reached if the services are waited for incorrectly.
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			err := store.PairAndConnect(ctx, address, pair, connect)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("PairAndConnect() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if paired != tt.wantPair || connected != tt.wantConnect {
+				t.Errorf("paired = %v, connected = %v, want %v, %v", paired, connected, tt.wantPair, tt.wantConnect)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package sessionstore
+
+import (
+	"reflect"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+)
+
+// Reconcile diffs the provided snapshot of adapters and devices against the contents of the store,
+// and applies the snapshot to the store. This is mainly used to resynchronize the store, for example
+// after a session reconnects to a server.
+//
+// Adapters and devices which are not in the store are added, those which are not in the snapshot
+// are removed, and those whose properties have changed are updated. The appropriate added, updated
+// and removed events are published for each change.
+func (s *SessionStore) Reconcile(adapters []bluetooth.AdapterData, devices []bluetooth.DeviceData) {
+	adapterSet := make(map[bluetooth.AdapterAddress]struct{}, len(adapters))
+	deviceSet := make(map[bluetooth.DeviceAddress]struct{}, len(devices))
+
+	for _, adapter := range adapters {
+		adapterSet[adapter.AdapterAddress] = struct{}{}
+
+		existing, ok := s.adapters.Load(adapter.AdapterAddress)
+		s.adapters.Store(adapter.AdapterAddress, adapter)
+
+		switch {
+		case !ok:
+			bluetooth.AdapterEvents().PublishAdded(adapter)
+
+		case !reflect.DeepEqual(existing, adapter):
+			bluetooth.AdapterEvents().PublishUpdated(adapter.AdapterEventData)
+		}
+	}
+
+	for _, device := range devices {
+		deviceSet[device.DeviceAddress] = struct{}{}
+	}
+
+	// Adapters are added before their devices, and removed after their devices,
+	// so that subscribers never observe a device without its associated adapter.
+	s.devices.Range(func(address bluetooth.DeviceAddress, device bluetooth.DeviceData) bool {
+		if _, ok := deviceSet[address]; !ok {
+			s.devices.Delete(address)
+			bluetooth.DeviceEvents().PublishRemoved(device.DeviceEventData)
+		}
+
+		return true
+	})
+
+	for _, device := range devices {
+		existing, ok := s.devices.Load(device.DeviceAddress)
+		s.devices.Store(device.DeviceAddress, device)
+
+		switch {
+		case !ok:
+			bluetooth.DeviceEvents().PublishAdded(device)
+
+		case !reflect.DeepEqual(existing, device):
+			bluetooth.DeviceEvents().PublishUpdated(device.DeviceEventData)
+		}
+	}
+
+	s.adapters.Range(func(address bluetooth.AdapterAddress, adapter bluetooth.AdapterData) bool {
+		if _, ok := adapterSet[address]; !ok {
+			s.adapters.Delete(address)
+			bluetooth.AdapterEvents().PublishRemoved(adapter.AdapterEventData)
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,196 @@
+package sessionstore
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+)
+
+// collectEvents records the events which are published to the event group, as "<action> <address>"
+// strings, until the returned function is called, which returns the sorted list of recorded events.
+func collectEvents[N bluetooth.NewDataEvents, U bluetooth.UpdatedDataEvents](t *testing.T, group bluetooth.EventGroup[N, U], added func(N) string, changed func(U) string) func() []string {
+	t.Helper()
+
+	sub, ok := group.Subscribe()
+	if !ok {
+		t.Fatal("cannot subscribe to events")
+	}
+
+	var (
+		events  []string
+		mu      sync.Mutex
+		done    = make(chan struct{})
+		stopped = make(chan struct{})
+	)
+
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		events = append(events, event)
+	}
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-done:
+				return
+
+			case data := <-sub.AddedEvents:
+				record("added " + added(data))
+
+			case data := <-sub.UpdatedEvents:
+				record("updated " + changed(data))
+
+			case data := <-sub.RemovedEvents:
+				record("removed " + changed(data))
+			}
+		}
+	}()
+
+	return func() []string {
+		time.Sleep(100 * time.Millisecond)
+		close(done)
+		<-stopped
+		sub.Unsubscribe()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		slices.Sort(events)
+
+		return events
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	mac := func(s string) bluetooth.MacAddress {
+		address, err := bluetooth.ParseMAC(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return address
+	}
+
+	adapterA := bluetooth.NewAdapterAddress(mac("00:00:00:00:00:0A"))
+	adapterB := bluetooth.NewAdapterAddress(mac("00:00:00:00:00:0B"))
+
+	adapter := func(address bluetooth.AdapterAddress, name string) bluetooth.AdapterData {
+		return bluetooth.AdapterData{AdapterEventData: bluetooth.AdapterEventData{AdapterAddress: address, Name: optional.New(name)}}
+	}
+	device := func(address string, adapter bluetooth.AdapterAddress, connected bool) bluetooth.DeviceData {
+		return bluetooth.DeviceData{DeviceEventData: bluetooth.DeviceEventData{
+			DeviceAddress: bluetooth.NewDeviceAddress(mac(address), adapter.Address),
+			Connected:     optional.New(connected),
+		}}
+	}
+
+	tests := []struct {
+		name             string
+		storedAdapters   []bluetooth.AdapterData
+		storedDevices    []bluetooth.DeviceData
+		snapshotAdapters []bluetooth.AdapterData
+		snapshotDevices  []bluetooth.DeviceData
+		wantAdapters     []string
+		wantDevices      []string
+	}{
+		{
+			name:             "unchanged",
+			storedAdapters:   []bluetooth.AdapterData{adapter(adapterA, "a")},
+			storedDevices:    []bluetooth.DeviceData{device("11:11:11:11:11:11", adapterA, true)},
+			snapshotAdapters: []bluetooth.AdapterData{adapter(adapterA, "a")},
+			snapshotDevices:  []bluetooth.DeviceData{device("11:11:11:11:11:11", adapterA, true)},
+		},
+		{
+			name:             "added",
+			snapshotAdapters: []bluetooth.AdapterData{adapter(adapterA, "a")},
+			snapshotDevices:  []bluetooth.DeviceData{device("11:11:11:11:11:11", adapterA, false)},
+			wantAdapters:     []string{"added 00:00:00:00:00:0A"},
+			wantDevices:      []string{"added 11:11:11:11:11:11"},
+		},
+		{
+			name:             "updated",
+			storedAdapters:   []bluetooth.AdapterData{adapter(adapterA, "a")},
+			storedDevices:    []bluetooth.DeviceData{device("11:11:11:11:11:11", adapterA, false)},
+			snapshotAdapters: []bluetooth.AdapterData{adapter(adapterA, "renamed")},
+			snapshotDevices:  []bluetooth.DeviceData{device("11:11:11:11:11:11", adapterA, true)},
+			wantAdapters:     []string{"updated 00:00:00:00:00:0A"},
+			wantDevices:      []string{"updated 11:11:11:11:11:11"},
+		},
+		{
+			name:           "removed",
+			storedAdapters: []bluetooth.AdapterData{adapter(adapterA, "a")},
+			storedDevices:  []bluetooth.DeviceData{device("11:11:11:11:11:11", adapterA, false)},
+			wantAdapters:   []string{"removed 00:00:00:00:00:0A"},
+			wantDevices:    []string{"removed 11:11:11:11:11:11"},
+		},
+		{
+			name:           "mixed",
+			storedAdapters: []bluetooth.AdapterData{adapter(adapterA, "a")},
+			storedDevices: []bluetooth.DeviceData{
+				device("11:11:11:11:11:11", adapterA, false),
+				device("22:22:22:22:22:22", adapterA, false),
+				device("33:33:33:33:33:33", adapterA, false),
+			},
+			snapshotAdapters: []bluetooth.AdapterData{adapter(adapterA, "a"), adapter(adapterB, "b")},
+			snapshotDevices: []bluetooth.DeviceData{
+				device("11:11:11:11:11:11", adapterA, false),
+				device("22:22:22:22:22:22", adapterA, true),
+				device("44:44:44:44:44:44", adapterB, false),
+			},
+			wantAdapters: []string{"added 00:00:00:00:00:0B"},
+			wantDevices: []string{
+				"added 44:44:44:44:44:44",
+				"removed 33:33:33:33:33:33",
+				"updated 22:22:22:22:22:22",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+			store.AddAdapters(tt.storedAdapters...)
+			store.AddDevices(tt.storedDevices...)
+
+			adapterEvents := collectEvents(t, bluetooth.AdapterEvents(),
+				func(a bluetooth.AdapterData) string { return a.Address.String() },
+				func(a bluetooth.AdapterEventData) string { return a.Address.String() },
+			)
+			deviceEvents := collectEvents(t, bluetooth.DeviceEvents(),
+				func(d bluetooth.DeviceData) string { return d.Address.String() },
+				func(d bluetooth.DeviceEventData) string { return d.Address.String() },
+			)
+
+			store.Reconcile(tt.snapshotAdapters, tt.snapshotDevices)
+
+			if got := adapterEvents(); !slices.Equal(got, tt.wantAdapters) {
+				t.Errorf("adapter events = %v, want %v", got, tt.wantAdapters)
+			}
+			if got := deviceEvents(); !slices.Equal(got, tt.wantDevices) {
+				t.Errorf("device events = %v, want %v", got, tt.wantDevices)
+			}
+
+			for _, want := range tt.snapshotDevices {
+				got, err := store.Device(want.DeviceAddress)
+				if err != nil || got.Connected.Value() != want.Connected.Value() {
+					t.Errorf("Device(%s) = %+v (err = %v), want %+v", want.Address, got, err, want)
+				}
+			}
+
+			if got := store.devices.Size(); got != len(tt.snapshotDevices) {
+				t.Errorf("store has %d devices, want %d", got, len(tt.snapshotDevices))
+			}
+			if got := store.adapters.Size(); got != len(tt.snapshotAdapters) {
+				t.Errorf("store has %d adapters, want %d", got, len(tt.snapshotAdapters))
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/config"
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 	"github.com/puzpuzpuz/xsync/v3"
 )
@@ -21,6 +22,12 @@ type MergeDeviceDataFunc func(*bluetooth.DeviceData) error
 type SessionStore struct {
 	adapters *xsync.MapOf[bluetooth.AdapterAddress, bluetooth.AdapterData]
 	devices  *xsync.MapOf[bluetooth.DeviceAddress, bluetooth.DeviceData]
+
+	transfers     *xsync.MapOf[bluetooth.ObjectPushTransferID, transferState]
+	transferStats *xsync.MapOf[bluetooth.DeviceAddress, bluetooth.TransferStats]
+
+	lowPower *lowPowerState
+	errors   *bluetooth.ErrorHistory
 }
 
 // NewSessionStore returns a new SessionStore.
@@ -28,6 +35,12 @@ func NewSessionStore() SessionStore {
 	return SessionStore{
 		adapters: xsync.NewMapOf[bluetooth.AdapterAddress, bluetooth.AdapterData](),
 		devices:  xsync.NewMapOf[bluetooth.DeviceAddress, bluetooth.DeviceData](),
+
+		transfers:     xsync.NewMapOf[bluetooth.ObjectPushTransferID, transferState](),
+		transferStats: xsync.NewMapOf[bluetooth.DeviceAddress, bluetooth.TransferStats](),
+
+		lowPower: &lowPowerState{changed: make(chan struct{})},
+		errors:   bluetooth.NewErrorHistory(config.DefaultErrorHistoryCapacity),
 	}
 }
 
@@ -114,6 +127,18 @@ func (s *SessionStore) UpdateAdapter(
 	return adapter.AdapterEventData, nil
 }
 
+// SetAdapterPowerState sets the power state of the adapter in the store.
+func (s *SessionStore) SetAdapterPowerState(
+	address bluetooth.AdapterAddress,
+	state bluetooth.AdapterPowerState,
+) (bluetooth.AdapterEventData, error) {
+	return s.UpdateAdapter(address, func(adapter *bluetooth.AdapterData) error {
+		adapter.PowerState = state
+
+		return nil
+	})
+}
+
 // Device returns a device which matches the provided address.
 func (s *SessionStore) Device(address bluetooth.DeviceAddress) (bluetooth.DeviceData, error) {
 	device, ok := s.devices.Load(address)
@@ -125,6 +150,34 @@ func (s *SessionStore) Device(address bluetooth.DeviceAddress) (bluetooth.Device
 	return device, nil
 }
 
+// SupportsObexTarget returns whether the device with the provided address advertises
+// the service of the Obex target within its service UUIDs.
+func (s *SessionStore) SupportsObexTarget(address bluetooth.MacAddress, target bluetooth.ObexTarget) (bool, error) {
+	serviceClass, ok := target.ServiceClass()
+	if !ok {
+		return false, fmt.Errorf("obex target %q: %w", target, errorkinds.ErrNotSupported)
+	}
+
+	var found, supported bool
+
+	s.devices.Range(func(key bluetooth.DeviceAddress, device bluetooth.DeviceData) bool {
+		if key.Address != address {
+			return true
+		}
+
+		found = true
+		supported = bluetooth.ServiceExists(device.UUIDs, serviceClass)
+
+		return !supported
+	})
+
+	if !found {
+		return false, fmt.Errorf("get %q: %w", address.String(), errorkinds.ErrDeviceNotFound)
+	}
+
+	return supported, nil
+}
+
 // AddDevice adds a device to the store.
 func (s *SessionStore) AddDevice(device bluetooth.DeviceData) {
 	s.devices.Store(device.DeviceAddress, device)
@@ -137,9 +190,11 @@ func (s *SessionStore) AddDevices(devices ...bluetooth.DeviceData) {
 	}
 }
 
-// RemoveDevice removes a device from the store.
-func (s *SessionStore) RemoveDevice(address bluetooth.DeviceAddress) {
-	s.devices.Delete(address)
+// RemoveDevice removes a device from the store, and reports whether the device was present.
+func (s *SessionStore) RemoveDevice(address bluetooth.DeviceAddress) bool {
+	_, ok := s.devices.LoadAndDelete(address)
+
+	return ok
 }
 
 // UpdateDevice updates the properties of the device in the store.
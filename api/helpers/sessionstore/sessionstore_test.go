@@ -0,0 +1,89 @@
+package sessionstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/google/uuid"
+)
+
+func TestSupportsObexTarget(t *testing.T) {
+	mac := bluetooth.MacAddress{0, 0x11, 0x22, 0x33, 0x44, 0x55}
+
+	first := bluetooth.NewDeviceAddress(mac, bluetooth.MacAddress{0, 0, 0, 0, 0, 1})
+	second := bluetooth.NewDeviceAddress(mac, bluetooth.MacAddress{0, 0, 0, 0, 0, 2})
+
+	var (
+		objectPush   = uuid.MustParse("00001105-0000-1000-8000-00805f9b34fb")
+		fileTransfer = uuid.MustParse("00001106-0000-1000-8000-00805f9b34fb")
+		phonebook    = uuid.MustParse("0000112f-0000-1000-8000-00805f9b34fb")
+		audioSink    = uuid.MustParse("0000110b-0000-1000-8000-00805f9b34fb")
+	)
+
+	tests := []struct {
+		name    string
+		devices map[bluetooth.DeviceAddress]uuid.UUIDs
+		target  bluetooth.ObexTarget
+		want    bool
+		wantErr error
+	}{
+		{
+			name:    "object push supported",
+			devices: map[bluetooth.DeviceAddress]uuid.UUIDs{first: {audioSink, objectPush}},
+			target:  bluetooth.ObexTargetObjectPush,
+			want:    true,
+		},
+		{
+			name:    "phonebook supported",
+			devices: map[bluetooth.DeviceAddress]uuid.UUIDs{first: {phonebook}},
+			target:  bluetooth.ObexTargetPhonebook,
+			want:    true,
+		},
+		{
+			name:    "file transfer not supported",
+			devices: map[bluetooth.DeviceAddress]uuid.UUIDs{first: {audioSink, objectPush}},
+			target:  bluetooth.ObexTargetFileTransfer,
+		},
+		{
+			name:    "no services",
+			devices: map[bluetooth.DeviceAddress]uuid.UUIDs{first: nil},
+			target:  bluetooth.ObexTargetObjectPush,
+		},
+		{
+			name:    "supported via another adapter",
+			devices: map[bluetooth.DeviceAddress]uuid.UUIDs{first: {audioSink}, second: {fileTransfer}},
+			target:  bluetooth.ObexTargetFileTransfer,
+			want:    true,
+		},
+		{
+			name:    "unknown target",
+			devices: map[bluetooth.DeviceAddress]uuid.UUIDs{first: {objectPush}},
+			target:  "bip",
+			wantErr: errorkinds.ErrNotSupported,
+		},
+		{
+			name:    "unknown device",
+			target:  bluetooth.ObexTargetObjectPush,
+			wantErr: errorkinds.ErrDeviceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+			for address, uuids := range tt.devices {
+				store.AddDevice(bluetooth.DeviceData{DeviceEventData: bluetooth.DeviceEventData{DeviceAddress: address, UUIDs: uuids}})
+			}
+
+			got, err := store.SupportsObexTarget(mac, tt.target)
+			if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("SupportsObexTarget() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SupportsObexTarget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
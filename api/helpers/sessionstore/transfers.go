@@ -0,0 +1,113 @@
+package sessionstore
+
+import (
+	"fmt"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+// transferState holds the state of a tracked transfer.
+type transferState struct {
+	address     bluetooth.DeviceAddress
+	receiving   bool
+	transferred uint64
+	size        uint64
+	ended       bool
+}
+
+// AddTransfer starts tracking a transfer, so that the transfer statistics of
+// the associated device can be updated once the transfer has ended.
+func (s *SessionStore) AddTransfer(transfer bluetooth.ObjectPushData) {
+	s.transfers.Store(transfer.TransferID, transferState{
+		address:     transfer.DeviceAddress,
+		receiving:   transfer.Receiving,
+		transferred: transfer.Transferred,
+		size:        transfer.Size,
+	})
+
+	s.UpdateTransfer(transfer.ObjectPushEventData)
+}
+
+// UpdateTransfer updates the state of a tracked transfer. If the transfer is complete or has errored,
+// the transfer statistics of the associated device are updated. Each transfer is only counted once.
+func (s *SessionStore) UpdateTransfer(transfer bluetooth.ObjectPushEventData) {
+	s.transfers.Compute(transfer.TransferID, func(state transferState, loaded bool) (transferState, bool) {
+		if !loaded {
+			return state, true
+		}
+
+		state.transferred = max(state.transferred, transfer.Transferred)
+		state.size = max(state.size, transfer.Size)
+
+		if state.ended {
+			return state, false
+		}
+
+		switch transfer.Status {
+		case bluetooth.TransferComplete:
+			state.ended = true
+			s.recordTransfer(state, true)
+
+		case bluetooth.TransferError:
+			state.ended = true
+			s.recordTransfer(state, false)
+		}
+
+		return state, false
+	})
+}
+
+// RemoveTransfer stops tracking a transfer. If the transfer was removed before it was
+// complete or had errored (for example, if it was cancelled), it is counted as a failure.
+func (s *SessionStore) RemoveTransfer(transfer bluetooth.ObjectPushEventData) {
+	state, ok := s.transfers.LoadAndDelete(transfer.TransferID)
+	if !ok || state.ended {
+		return
+	}
+
+	s.recordTransfer(state, false)
+}
+
+// TransferStats returns the transfer statistics of the device.
+func (s *SessionStore) TransferStats(address bluetooth.DeviceAddress) (bluetooth.TransferStats, error) {
+	stats, ok := s.transferStats.Load(address)
+	if !ok {
+		if _, err := s.Device(address); err != nil {
+			return stats, fmt.Errorf("transfer stats %q: %w", address.Address.String(), errorkinds.ErrDeviceNotFound)
+		}
+	}
+
+	return stats, nil
+}
+
+// ResetTransferStats resets the transfer statistics of the device.
+func (s *SessionStore) ResetTransferStats(address bluetooth.DeviceAddress) error {
+	if _, ok := s.transferStats.LoadAndDelete(address); !ok {
+		if _, err := s.Device(address); err != nil {
+			return fmt.Errorf("reset transfer stats %q: %w", address.Address.String(), errorkinds.ErrDeviceNotFound)
+		}
+	}
+
+	return nil
+}
+
+// recordTransfer adds an ended transfer to the transfer statistics of the associated device.
+func (s *SessionStore) recordTransfer(state transferState, complete bool) {
+	s.transferStats.Compute(state.address, func(stats bluetooth.TransferStats, _ bool) (bluetooth.TransferStats, bool) {
+		switch {
+		case !complete:
+			stats.Failures++
+
+		case state.receiving:
+			stats.FilesReceived++
+			stats.BytesReceived += max(state.transferred, state.size)
+
+		default:
+			stats.FilesSent++
+			stats.BytesSent += max(state.transferred, state.size)
+		}
+
+		return stats, false
+	})
+}
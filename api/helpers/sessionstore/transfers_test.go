@@ -0,0 +1,135 @@
+package sessionstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+func TestTransferStats(t *testing.T) {
+	address := bluetooth.NewDeviceAddress(bluetooth.MacAddress{0, 0x11, 0x22, 0x33, 0x44, 0x55}, bluetooth.MacAddress{0, 0, 0, 0, 0, 1})
+
+	transfer := func(id bluetooth.ObjectPushTransferID, receiving bool, size uint64) bluetooth.ObjectPushData {
+		return bluetooth.ObjectPushData{
+			Receiving: receiving,
+			ObjectPushEventData: bluetooth.ObjectPushEventData{
+				DeviceAddress: address,
+				TransferID:    id,
+				Status:        bluetooth.TransferQueued,
+				Size:          size,
+			},
+		}
+	}
+
+	// A step either adds the transfer, updates it with the status, or removes it if the status is empty.
+	type step struct {
+		transfer bluetooth.ObjectPushData
+		status   bluetooth.ObjectPushStatus
+		add      bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+		want  bluetooth.TransferStats
+	}{
+		{
+			name: "sent",
+			steps: []step{
+				{transfer: transfer("t0", false, 100), add: true},
+				{transfer: transfer("t0", false, 100), status: bluetooth.TransferComplete},
+			},
+			want: bluetooth.TransferStats{FilesSent: 1, BytesSent: 100},
+		},
+		{
+			name: "received",
+			steps: []step{
+				{transfer: transfer("t0", true, 50), add: true},
+				{transfer: transfer("t0", true, 50), status: bluetooth.TransferComplete},
+			},
+			want: bluetooth.TransferStats{FilesReceived: 1, BytesReceived: 50},
+		},
+		{
+			name: "incrementing",
+			steps: []step{
+				{transfer: transfer("t0", false, 100), add: true},
+				{transfer: transfer("t0", false, 100), status: bluetooth.TransferComplete},
+				{transfer: transfer("t1", false, 200), add: true},
+				{transfer: transfer("t1", false, 200), status: bluetooth.TransferComplete},
+				{transfer: transfer("t2", true, 10), add: true},
+				{transfer: transfer("t2", true, 10), status: bluetooth.TransferComplete},
+			},
+			want: bluetooth.TransferStats{FilesSent: 2, BytesSent: 300, FilesReceived: 1, BytesReceived: 10},
+		},
+		{
+			name: "counted once",
+			steps: []step{
+				{transfer: transfer("t0", false, 100), add: true},
+				{transfer: transfer("t0", false, 100), status: bluetooth.TransferComplete},
+				{transfer: transfer("t0", false, 100), status: bluetooth.TransferComplete},
+				{transfer: transfer("t0", false, 100)},
+			},
+			want: bluetooth.TransferStats{FilesSent: 1, BytesSent: 100},
+		},
+		{
+			name: "errored and cancelled",
+			steps: []step{
+				{transfer: transfer("t0", false, 100), add: true},
+				{transfer: transfer("t0", false, 100), status: bluetooth.TransferError},
+				{transfer: transfer("t1", true, 100), add: true},
+				{transfer: transfer("t1", true, 100), status: bluetooth.TransferActive},
+				{transfer: transfer("t1", true, 100)},
+			},
+			want: bluetooth.TransferStats{Failures: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+			store.AddDevice(bluetooth.DeviceData{DeviceEventData: bluetooth.DeviceEventData{DeviceAddress: address}})
+
+			for _, step := range tt.steps {
+				data := step.transfer
+				data.Status = step.status
+
+				switch {
+				case step.add:
+					store.AddTransfer(step.transfer)
+
+				case step.status == "":
+					store.RemoveTransfer(data.ObjectPushEventData)
+
+				default:
+					store.UpdateTransfer(data.ObjectPushEventData)
+				}
+			}
+
+			if got, err := store.TransferStats(address); err != nil || got != tt.want {
+				t.Fatalf("TransferStats() = (%+v, %v), want %+v", got, err, tt.want)
+			}
+
+			if err := store.ResetTransferStats(address); err != nil {
+				t.Fatalf("ResetTransferStats() error = %v", err)
+			}
+
+			if got, err := store.TransferStats(address); err != nil || got != (bluetooth.TransferStats{}) {
+				t.Errorf("TransferStats() after reset = (%+v, %v), want no statistics", got, err)
+			}
+		})
+	}
+}
+
+func TestTransferStatsUnknownDevice(t *testing.T) {
+	address := bluetooth.NewDeviceAddress(bluetooth.MacAddress{0, 0x11, 0x22, 0x33, 0x44, 0x55}, bluetooth.MacAddress{0, 0, 0, 0, 0, 1})
+	store := NewSessionStore()
+
+	if _, err := store.TransferStats(address); !errors.Is(err, errorkinds.ErrDeviceNotFound) {
+		t.Errorf("TransferStats() error = %v, want %v", err, errorkinds.ErrDeviceNotFound)
+	}
+	if err := store.ResetTransferStats(address); !errors.Is(err, errorkinds.ErrDeviceNotFound) {
+		t.Errorf("ResetTransferStats() error = %v, want %v", err, errorkinds.ErrDeviceNotFound)
+	}
+}
@@ -0,0 +1,171 @@
+package sessionstore
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/google/uuid"
+)
+
+// WatchServicesResolved waits for the services of the device to be resolved, and sends
+// the device's service UUIDs once via the returned channel. If the services are already
+// resolved within the store, the UUIDs are sent immediately.
+//
+// The channel is closed once the UUIDs are sent, the context (ctx) is cancelled,
+// or the returned function is called.
+func (s *SessionStore) WatchServicesResolved(ctx context.Context, address bluetooth.DeviceAddress) (<-chan []uuid.UUID, func()) {
+	resolved := make(chan []uuid.UUID, 1)
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Subscribe before checking the store, so that an update which
+	// arrives in between is not missed.
+	sub, ok := bluetooth.DeviceEvents().Subscribe()
+	if !ok {
+		close(resolved)
+		return resolved, cancel
+	}
+
+	go func() {
+		defer close(resolved)
+		defer sub.Unsubscribe()
+
+		if device, err := s.Device(address); err == nil && servicesResolved(device.DeviceEventData) {
+			resolved <- device.UUIDs
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case device, ok := <-sub.UpdatedEvents:
+				if !ok {
+					return
+				}
+
+				if device.DeviceAddress != address || !servicesResolved(device) {
+					continue
+				}
+
+				resolved <- device.UUIDs
+
+				return
+			}
+		}
+	}()
+
+	return resolved, cancel
+}
+
+// WatchConnectedDevices sends the list of connected devices via the returned channel, first with
+// the devices that are currently connected within the store, and then each time a device connects
+// or disconnects. If the receiver has not read a previously sent list, it is replaced with the latest list.
+//
+// The channel is closed once the context (ctx) is cancelled, or the returned function is called.
+func (s *SessionStore) WatchConnectedDevices(ctx context.Context) (<-chan []bluetooth.DeviceData, func()) {
+	connected := make(chan []bluetooth.DeviceData, 1)
+	ctx, cancel := context.WithCancel(ctx)
+
+	sub, ok := bluetooth.DeviceEvents().Subscribe()
+	if !ok {
+		close(connected)
+		return connected, cancel
+	}
+
+	go func() {
+		defer close(connected)
+		defer sub.Unsubscribe()
+
+		devices := s.connectedDevices()
+		connected <- devices
+
+		for {
+			var removed bluetooth.DeviceAddress
+
+			select {
+			case <-ctx.Done():
+				return
+
+			case _, ok := <-sub.AddedEvents:
+				if !ok {
+					return
+				}
+
+			case _, ok := <-sub.UpdatedEvents:
+				if !ok {
+					return
+				}
+
+			case device, ok := <-sub.RemovedEvents:
+				if !ok {
+					return
+				}
+
+				removed = device.DeviceAddress
+			}
+
+			current := slices.DeleteFunc(s.connectedDevices(), func(device bluetooth.DeviceData) bool {
+				return device.DeviceAddress == removed
+			})
+			if slices.EqualFunc(devices, current, func(d1, d2 bluetooth.DeviceData) bool {
+				return d1.DeviceAddress == d2.DeviceAddress
+			}) {
+				continue
+			}
+
+			devices = current
+
+			select {
+			case <-connected:
+			default:
+			}
+
+			connected <- devices
+		}
+	}()
+
+	return connected, cancel
+}
+
+// connectedDevices returns the list of connected devices within the store, sorted by address.
+func (s *SessionStore) connectedDevices() []bluetooth.DeviceData {
+	var devices []bluetooth.DeviceData
+
+	s.devices.Range(func(_ bluetooth.DeviceAddress, device bluetooth.DeviceData) bool {
+		if device.Connected.Value() {
+			devices = append(devices, device)
+		}
+
+		return true
+	})
+
+	slices.SortFunc(devices, func(d1, d2 bluetooth.DeviceData) int {
+		if c := strings.Compare(d1.AssociatedAdapter.String(), d2.AssociatedAdapter.String()); c != 0 {
+			return c
+		}
+
+		return strings.Compare(d1.Address.String(), d2.Address.String())
+	})
+
+	return devices
+}
+
+// servicesResolved returns whether the services of the device have been resolved.
+// The services of a paired device with known UUIDs are considered resolved, since some
+// backends (for example BlueZ) report 'ServicesResolved' as false while the device is
+// disconnected. If the 'ServicesResolved' property is not provided by the backend, the
+// services are assumed to be resolved if the device is connected and has advertised its UUIDs.
+func servicesResolved(device bluetooth.DeviceEventData) bool {
+	if device.Paired.Value() && len(device.UUIDs) > 0 {
+		return true
+	}
+
+	if resolved, ok := device.ServicesResolved.Get(); ok {
+		return resolved
+	}
+
+	return device.Connected.Value() && len(device.UUIDs) > 0
+}
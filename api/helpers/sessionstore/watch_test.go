@@ -0,0 +1,244 @@
+package sessionstore
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+	"github.com/google/uuid"
+)
+
+func TestServicesResolved(t *testing.T) {
+	uuids := uuid.UUIDs{uuid.MustParse("0000110b-0000-1000-8000-00805f9b34fb")}
+
+	tests := []struct {
+		name   string
+		device bluetooth.DeviceEventData
+		want   bool
+	}{
+		{"resolved", bluetooth.DeviceEventData{ServicesResolved: optional.New(true)}, true},
+		{"not resolved", bluetooth.DeviceEventData{ServicesResolved: optional.New(false), Connected: optional.New(true), UUIDs: uuids}, false},
+		{"fallback connected with UUIDs", bluetooth.DeviceEventData{Connected: optional.New(true), UUIDs: uuids}, true},
+		{"fallback connected without UUIDs", bluetooth.DeviceEventData{Connected: optional.New(true)}, false},
+		{"fallback disconnected with UUIDs", bluetooth.DeviceEventData{Connected: optional.New(false), UUIDs: uuids}, false},
+		{"paired disconnected with UUIDs", bluetooth.DeviceEventData{Paired: optional.New(true), ServicesResolved: optional.New(false), UUIDs: uuids}, true},
+		{"paired without UUIDs", bluetooth.DeviceEventData{Paired: optional.New(true), ServicesResolved: optional.New(false)}, false},
+		{"empty", bluetooth.DeviceEventData{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := servicesResolved(tt.device); got != tt.want {
+				t.Errorf("servicesResolved() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchServicesResolved(t *testing.T) {
+	mac, _ := bluetooth.ParseMAC("00:11:22:33:44:55")
+	otherMac, _ := bluetooth.ParseMAC("66:77:88:99:AA:BB")
+
+	address := bluetooth.NewDeviceAddress(mac, mac)
+	other := bluetooth.NewDeviceAddress(otherMac, mac)
+	uuids := uuid.UUIDs{uuid.MustParse("0000110b-0000-1000-8000-00805f9b34fb")}
+
+	tests := []struct {
+		name    string
+		stored  bluetooth.DeviceEventData
+		updates []bluetooth.DeviceEventData
+		want    uuid.UUIDs
+	}{
+		{
+			name:   "already resolved in store",
+			stored: bluetooth.DeviceEventData{DeviceAddress: address, ServicesResolved: optional.New(true), UUIDs: uuids},
+			want:   uuids,
+		},
+		{
+			name:   "resolved by update",
+			stored: bluetooth.DeviceEventData{DeviceAddress: address},
+			updates: []bluetooth.DeviceEventData{
+				{DeviceAddress: address, ServicesResolved: optional.New(false)},
+				{DeviceAddress: address, ServicesResolved: optional.New(true), UUIDs: uuids},
+			},
+			want: uuids,
+		},
+		{
+			name:   "resolved by fallback update",
+			stored: bluetooth.DeviceEventData{DeviceAddress: address},
+			updates: []bluetooth.DeviceEventData{
+				{DeviceAddress: address, Connected: optional.New(true), UUIDs: uuids},
+			},
+			want: uuids,
+		},
+		{
+			name:   "other device resolved",
+			stored: bluetooth.DeviceEventData{DeviceAddress: address},
+			updates: []bluetooth.DeviceEventData{
+				{DeviceAddress: other, ServicesResolved: optional.New(true), UUIDs: uuids},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+			store.AddDevice(bluetooth.DeviceData{DeviceEventData: tt.stored})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			resolved, stop := store.WatchServicesResolved(ctx, address)
+			defer stop()
+
+			// Events which are not received immediately are dropped, so
+			// each update is published once the watcher can receive it.
+			for _, update := range tt.updates {
+				time.Sleep(20 * time.Millisecond)
+				bluetooth.DeviceEvents().PublishUpdated(update)
+			}
+
+			got, ok := <-resolved
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("received %v, want no UUIDs", got)
+				}
+
+				return
+			}
+
+			if !ok || !slices.Equal(got, tt.want) {
+				t.Fatalf("received %v (ok = %v), want %v", got, ok, tt.want)
+			}
+
+			if _, ok := <-resolved; ok {
+				t.Error("channel was not closed after the UUIDs were sent")
+			}
+		})
+	}
+}
+
+func TestWatchConnectedDevices(t *testing.T) {
+	adapter := bluetooth.MacAddress{0, 0, 0, 0, 0, 1}
+	first := bluetooth.NewDeviceAddress(bluetooth.MacAddress{0, 0x11, 0x22, 0x33, 0x44, 0x55}, adapter)
+	second := bluetooth.NewDeviceAddress(bluetooth.MacAddress{0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb}, adapter)
+
+	device := func(address bluetooth.DeviceAddress, connected bool) bluetooth.DeviceEventData {
+		return bluetooth.DeviceEventData{DeviceAddress: address, Connected: optional.New(connected)}
+	}
+
+	tests := []struct {
+		name        string
+		stored      []bluetooth.DeviceEventData
+		action      bluetooth.EventAction
+		change      bluetooth.DeviceEventData
+		wantInitial []bluetooth.DeviceAddress
+		want        []bluetooth.DeviceAddress
+	}{
+		{
+			name:        "device connected",
+			stored:      []bluetooth.DeviceEventData{device(first, true), device(second, false)},
+			action:      bluetooth.EventActionUpdated,
+			change:      device(second, true),
+			wantInitial: []bluetooth.DeviceAddress{first},
+			want:        []bluetooth.DeviceAddress{first, second},
+		},
+		{
+			name:        "device disconnected",
+			stored:      []bluetooth.DeviceEventData{device(first, true), device(second, true)},
+			action:      bluetooth.EventActionUpdated,
+			change:      device(first, false),
+			wantInitial: []bluetooth.DeviceAddress{first, second},
+			want:        []bluetooth.DeviceAddress{second},
+		},
+		{
+			name:        "connected device added",
+			stored:      []bluetooth.DeviceEventData{device(first, true)},
+			action:      bluetooth.EventActionAdded,
+			change:      device(second, true),
+			wantInitial: []bluetooth.DeviceAddress{first},
+			want:        []bluetooth.DeviceAddress{first, second},
+		},
+		{
+			name:        "connected device removed",
+			stored:      []bluetooth.DeviceEventData{device(first, true), device(second, true)},
+			action:      bluetooth.EventActionRemoved,
+			change:      device(second, true),
+			wantInitial: []bluetooth.DeviceAddress{first, second},
+			want:        []bluetooth.DeviceAddress{first},
+		},
+		{
+			name:        "connection state unchanged",
+			stored:      []bluetooth.DeviceEventData{device(first, true), device(second, false)},
+			action:      bluetooth.EventActionUpdated,
+			change:      device(second, false),
+			wantInitial: []bluetooth.DeviceAddress{first},
+		},
+	}
+
+	addresses := func(devices []bluetooth.DeviceData) []bluetooth.DeviceAddress {
+		var addresses []bluetooth.DeviceAddress
+		for _, device := range devices {
+			addresses = append(addresses, device.DeviceAddress)
+		}
+
+		return addresses
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewSessionStore()
+			for _, stored := range tt.stored {
+				store.AddDevice(bluetooth.DeviceData{DeviceEventData: stored})
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			connected, stop := store.WatchConnectedDevices(ctx)
+
+			if got := addresses(<-connected); !slices.Equal(got, tt.wantInitial) {
+				t.Fatalf("initial connected devices = %v, want %v", got, tt.wantInitial)
+			}
+
+			change := bluetooth.DeviceData{DeviceEventData: tt.change}
+
+			switch tt.action {
+			case bluetooth.EventActionAdded:
+				store.AddDevice(change)
+				bluetooth.DeviceEvents().PublishAdded(change)
+
+			case bluetooth.EventActionUpdated:
+				store.AddDevice(change)
+				bluetooth.DeviceEvents().PublishUpdated(change.DeviceEventData)
+
+			case bluetooth.EventActionRemoved:
+				// The device is published before it is removed from the store,
+				// so the removed device must not be reported as connected.
+				bluetooth.DeviceEvents().PublishRemoved(change.DeviceEventData)
+			}
+
+			select {
+			case devices := <-connected:
+				if got := addresses(devices); tt.want == nil || !slices.Equal(got, tt.want) {
+					t.Errorf("connected devices = %v, want %v", got, tt.want)
+				}
+
+			case <-time.After(200 * time.Millisecond):
+				if tt.want != nil {
+					t.Errorf("connected devices were not sent, want %v", tt.want)
+				}
+			}
+
+			stop()
+
+			if _, ok := <-connected; ok {
+				t.Error("channel was not closed after the watch was stopped")
+			}
+		})
+	}
+}
@@ -7,8 +7,34 @@ type PlatformInfo struct {
 	OS             string `json:"os_info,omitempty"`
 	Stack          string `json:"stack,omitempty"`
 	Implementation string
+
+	// ExperimentalFeatures holds the identifiers of the experimental features
+	// that are enabled within the Bluetooth daemon, if the daemon exposes them.
+	ExperimentalFeatures []string `json:"experimental_features,omitempty"`
+
+	// StackVersion holds the version of the Bluetooth stack, if it can be determined.
+	// This is currently only populated on Linux, where it holds the version of BlueZ.
+	StackVersion string `json:"stack_version,omitempty"`
+
+	// AdapterAddress holds the address of the default adapter.
+	AdapterAddress string `json:"adapter_address,omitempty"`
+
+	// AdapterAddressType holds the type of the default adapter's address,
+	// which is either "public" or "random". This is currently only populated on Linux.
+	AdapterAddressType string `json:"adapter_address_type,omitempty"`
+
+	// Transports holds the transports that are supported by the default adapter,
+	// which can be "bredr" (Bluetooth Classic) and "le" (Bluetooth Low Energy).
+	// This is currently only populated on Linux.
+	Transports []string `json:"transports,omitempty"`
 }
 
+// The different transports that can be supported by an adapter.
+const (
+	TransportBREDR = "bredr"
+	TransportLE    = "le"
+)
+
 // NewPlatformInfo returns a new PlatformInfo.
 func NewPlatformInfo(stack, impl string) PlatformInfo {
 	return PlatformInfo{
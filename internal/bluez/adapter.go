@@ -4,6 +4,7 @@ package bluez
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 
 	"github.com/Southclaws/fault"
@@ -12,6 +13,7 @@ import (
 	"github.com/Southclaws/fault/ftag"
 	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	errorkinds "github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
 	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
 	"github.com/godbus/dbus/v5"
 )
@@ -73,11 +75,18 @@ func (a *adapter) StopDiscovery() error {
 
 // SetPoweredState sets the powered state of the adapter.
 func (a *adapter) SetPoweredState(enable bool) error {
-	if _, err := a.check(); err != nil {
+	adapter, err := a.check()
+	if err != nil {
 		return err
 	}
 
+	if powered, ok := adapter.Powered.Get(); !ok || powered != enable {
+		a.publishPowerState(bluetooth.PowerStateTransition(enable))
+	}
+
 	if err := a.setAdapterProperty("Powered", enable); err != nil {
+		a.publishPowerState(bluetooth.PowerStateFromPowered(adapter.Powered))
+
 		return fault.Wrap(
 			err,
 			fctx.With(
@@ -90,6 +99,8 @@ func (a *adapter) SetPoweredState(enable bool) error {
 		)
 	}
 
+	a.publishPowerState(bluetooth.PowerStateFromPowered(optional.New(enable)))
+
 	return nil
 }
 
@@ -166,6 +177,123 @@ func (a *adapter) Devices() ([]bluetooth.DeviceData, error) {
 	return devices, nil
 }
 
+// RemoveAllDevices removes (unpairs and forgets) all the devices associated with the adapter.
+// If a device cannot be removed, the remaining devices are still removed, and all the
+// errors that occurred are returned together.
+func (a *adapter) RemoveAllDevices() error {
+	devices, err := a.Devices()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, device := range devices {
+		if err := a.b.Device(device.DeviceAddress).Remove(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		a.b.store.RemoveDevice(device.DeviceAddress)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "adapter-remove-all-devices",
+				"address", a.key.Address.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("Some devices could not be removed"),
+		)
+	}
+
+	return nil
+}
+
+// SetProperty sets a raw property of the adapter.
+// This is an unstable API, which is meant to access properties that are not yet exposed
+// by the typed API. The property name and value type must match the Bluez Adapter1 interface.
+func (a *adapter) SetProperty(name string, value any) error {
+	if _, err := a.check(); err != nil {
+		return err
+	}
+
+	obj := a.b.systemBus.Object(dbh.BluezBusName, a.path)
+	if err := dbh.CheckProperty(obj, dbh.BluezAdapterIface, name, true); err != nil {
+		return fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "adapter-setproperty-check",
+				"property", name,
+				"address", a.key.Address.String(),
+			),
+			ftag.With(ftag.InvalidArgument),
+			fmsg.With("Cannot set adapter property"),
+		)
+	}
+
+	if err := a.setAdapterProperty(name, value); err != nil {
+		return fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "adapter-setproperty",
+				"property", name,
+				"address", a.key.Address.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("An error occurred on setting adapter property"),
+		)
+	}
+
+	return nil
+}
+
+// GetProperty gets a raw property of the adapter.
+// This is an unstable API, which is meant to access properties that are not yet exposed
+// by the typed API. The property name must match the Bluez Adapter1 interface.
+func (a *adapter) GetProperty(name string) (any, error) {
+	if _, err := a.check(); err != nil {
+		return nil, err
+	}
+
+	obj := a.b.systemBus.Object(dbh.BluezBusName, a.path)
+	if err := dbh.CheckProperty(obj, dbh.BluezAdapterIface, name, false); err != nil {
+		return nil, fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "adapter-getproperty-check",
+				"property", name,
+				"address", a.key.Address.String(),
+			),
+			ftag.With(ftag.InvalidArgument),
+			fmsg.With("Cannot get adapter property"),
+		)
+	}
+
+	value, err := obj.GetProperty(dbh.BluezAdapterIface + "." + name)
+	if err != nil {
+		return nil, fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "adapter-getproperty",
+				"property", name,
+				"address", a.key.Address.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("An error occurred on getting adapter property"),
+		)
+	}
+
+	return value.Value(), nil
+}
+
 // check validates whether a valid DBus path is associated with the provided
 // adapter's address ((*Adapter).Address), and checks whether the adapter
 // properties are present within the global session store.
@@ -224,6 +352,37 @@ func (a *adapter) callAdapter(method string, flags dbus.Flags, args ...any) *dbu
 		Call(dbh.BluezAdapterIface+"."+method, flags, args...)
 }
 
+// refresh fetches the adapter properties, stores them within the session store,
+// and publishes an adapter update event.
+func (a *adapter) refresh() (bluetooth.AdapterData, error) {
+	if _, err := a.check(); err != nil {
+		return bluetooth.AdapterData{}, err
+	}
+
+	values, err := a.adapterProperties()
+	if err != nil {
+		return bluetooth.AdapterData{}, fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "adapter-refresh-properties",
+				"address", a.key.Address.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("Cannot fetch adapter properties"),
+		)
+	}
+
+	adapter, err := a.convertAndStoreObjects(values)
+	if err != nil {
+		return adapter, err
+	}
+
+	bluetooth.AdapterEvents().PublishUpdated(adapter.AdapterEventData)
+
+	return adapter, nil
+}
+
 // adapterProperties gathers all the properties for a bluetooth adapter.
 func (a *adapter) adapterProperties() (map[string]dbus.Variant, error) {
 	result := make(map[string]dbus.Variant)
@@ -244,6 +403,16 @@ func (a *adapter) setAdapterProperty(key string, value any) error {
 	).Store()
 }
 
+// publishPowerState updates the power state of the adapter in the store, and publishes the update.
+func (a *adapter) publishPowerState(state bluetooth.AdapterPowerState) {
+	updated, err := a.b.store.SetAdapterPowerState(a.key, state)
+	if err != nil {
+		return
+	}
+
+	bluetooth.AdapterEvents().PublishUpdated(updated)
+}
+
 // convertAndStoreObjectseObjectseObjects converts a map of dbus objects to a common AdapterData structure.
 func (a *adapter) convertAndStoreObjects(values map[string]dbus.Variant) (bluetooth.AdapterData, error) {
 	/*
@@ -281,6 +450,9 @@ func (a *adapter) convertAndStoreObjects(values map[string]dbus.Variant) (blueto
 	dbh.PathConverter.AddAdapterDbusPath(a.path, adapter.AdapterAddress)
 	adapter.UniqueName = filepath.Base(string(a.path))
 
+	adapter.PowerState = bluetooth.PowerStateUnknown
+	dbh.DecodeAdapterPowerState(values, &adapter)
+
 	a.b.store.AddAdapter(adapter)
 
 	return adapter, nil
@@ -0,0 +1,123 @@
+//go:build linux
+
+package bluez
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
+	"github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbustest"
+	"github.com/godbus/dbus/v5"
+)
+
+func TestSetPoweredStateTransitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		powered optional.Optional[bool]
+		enable  bool
+		fail    bool
+		want    []bluetooth.AdapterPowerState
+	}{
+		{
+			name:    "power on",
+			powered: optional.New(false),
+			enable:  true,
+			want:    []bluetooth.AdapterPowerState{bluetooth.PowerStatePoweringOn, bluetooth.PowerStateOn},
+		},
+		{
+			name:    "power off",
+			powered: optional.New(true),
+			want:    []bluetooth.AdapterPowerState{bluetooth.PowerStatePoweringOff, bluetooth.PowerStateOff},
+		},
+		{
+			name:    "power on failed",
+			powered: optional.New(false),
+			enable:  true,
+			fail:    true,
+			want:    []bluetooth.AdapterPowerState{bluetooth.PowerStatePoweringOn, bluetooth.PowerStateOff},
+		},
+		{
+			name:   "power on from an unknown state failed",
+			enable: true,
+			fail:   true,
+			want:   []bluetooth.AdapterPowerState{bluetooth.PowerStatePoweringOn, bluetooth.PowerStateUnknown},
+		},
+		{
+			name:    "already powered on",
+			powered: optional.New(true),
+			enable:  true,
+			want:    []bluetooth.AdapterPowerState{bluetooth.PowerStateOn},
+		},
+	}
+
+	path := dbus.ObjectPath("/org/bluez/hci0")
+	address := bluetooth.NewAdapterAddress(bluetooth.MacAddress{0, 0, 0, 0, 0, 1})
+
+	dbh.PathConverter.AddAdapterDbusPath(path, address)
+	t.Cleanup(func() { dbh.PathConverter.RemoveAdapterDbusPath(path) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbustest.NewBus(t, dbh.BluezBusName)
+
+			if err := conn.ExportMethodTable(map[string]any{
+				"Set": func(string, string, dbus.Variant) *dbus.Error {
+					if tt.fail {
+						return dbus.MakeFailedError(errors.New("rfkill blocked"))
+					}
+
+					return nil
+				},
+			}, path, "org.freedesktop.DBus.Properties"); err != nil {
+				t.Fatal(err)
+			}
+
+			b := &DbusSession{systemBus: conn, store: sessionstore.NewSessionStore()}
+			b.store.AddAdapter(bluetooth.AdapterData{AdapterEventData: bluetooth.AdapterEventData{
+				AdapterAddress: address,
+				Powered:        tt.powered,
+				PowerState:     bluetooth.PowerStateFromPowered(tt.powered),
+			}})
+
+			sub, ok := bluetooth.AdapterEvents().Subscribe()
+			if !ok {
+				t.Fatal("cannot subscribe to adapter events")
+			}
+			defer sub.Unsubscribe()
+
+			// The events are buffered until the power state is set, since
+			// events which are not received immediately are otherwise dropped.
+			sub.Pause(bluetooth.PauseMode{BufferSize: 10})
+
+			if err := b.Adapter(address).SetPoweredState(tt.enable); (err != nil) != tt.fail {
+				t.Fatalf("SetPoweredState() error = %v, want error %v", err, tt.fail)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			sub.Resume()
+
+			var got []bluetooth.AdapterPowerState
+
+		Receive:
+			for {
+				select {
+				case adapter := <-sub.UpdatedEvents:
+					got = append(got, adapter.PowerState)
+
+				case <-time.After(100 * time.Millisecond):
+					break Receive
+				}
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("power state transitions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
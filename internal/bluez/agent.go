@@ -4,9 +4,12 @@ package bluez
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	errorkinds "github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	sstore "github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
 	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
 	"github.com/godbus/dbus/v5"
 	"github.com/godbus/dbus/v5/introspect"
@@ -16,28 +19,38 @@ import (
 // agent describes an Bluez agent connection.
 // Note that, all public methods are exported to the Bluez Agent Manager
 // via the system bus, and hence is called by the Agent Manager only.
-// Any errors are published to the global error event stream.
+// Any errors are published to the error event stream of the session.
 type agent struct {
 	systemBus *dbus.Conn
+	store     *sstore.SessionStore
 
 	authHandler bluetooth.SessionAuthorizer
 	authTimeout time.Duration
 	ctx         bluetooth.AuthTimeout
 
+	passkeyEntered map[bluetooth.DeviceAddress]uint16
+
 	initialized bool
+
+	mu      sync.Mutex
+	pairing sync.RWMutex
 }
 
 const (
 	agentPinCode        = "0000"
 	agentPassKey uint32 = 1024
+
+	agentCapability = bluetooth.CapabilityKeyboardDisplay
 )
 
 // newAgent returns a new BlueZ agent.
-func newAgent(systemBus *dbus.Conn, authHandler bluetooth.SessionAuthorizer, authTimeout time.Duration) *agent {
+func newAgent(systemBus *dbus.Conn, store *sstore.SessionStore, authHandler bluetooth.SessionAuthorizer, authTimeout time.Duration) *agent {
 	return &agent{
-		systemBus:   systemBus,
-		authHandler: authHandler,
-		authTimeout: authTimeout,
+		systemBus:      systemBus,
+		store:          store,
+		authHandler:    authHandler,
+		authTimeout:    authTimeout,
+		passkeyEntered: make(map[bluetooth.DeviceAddress]uint16),
 	}
 }
 
@@ -67,17 +80,83 @@ func (b *agent) setup() error {
 		return err
 	}
 
-	if err := b.callAgentManager("RegisterAgent", dbh.BluezAgentPath, "KeyboardDisplay").Store(); err != nil {
+	if err := b.register(agentCapability); err != nil {
 		return err
 	}
 
-	if err := b.callAgentManager("RequestDefaultAgent", dbh.BluezAgentPath).Store(); err != nil {
+	b.initialized = true
+
+	return nil
+}
+
+// register registers the agent with the provided capability, and sets it as the default agent.
+func (b *agent) register(capability bluetooth.AgentCapability) error {
+	if err := b.callAgentManager(b.systemBus, "RegisterAgent", dbh.BluezAgentPath, string(capability)).Store(); err != nil {
 		return err
 	}
 
-	b.initialized = true
+	return b.callAgentManager(b.systemBus, "RequestDefaultAgent", dbh.BluezAgentPath).Store()
+}
 
-	return nil
+// withDefaultCapability calls the function (fn) with the connection of the default agent. Any number
+// of such calls can be in progress at a time. If a call to 'withCapability' is in progress, this waits
+// until it is complete, since the agent's authentication state is shared. Such a call is bounded by
+// the operation timeout of its pairing attempt.
+func (b *agent) withDefaultCapability(fn func(conn *dbus.Conn) error) error {
+	b.pairing.RLock()
+	defer b.pairing.RUnlock()
+
+	return fn(b.systemBus)
+}
+
+// withCapability registers the agent with the provided capability on a separate connection to the
+// system bus, and calls the function (fn) with that connection. Since Bluez allows only one agent per
+// connection, and uses the agent of the connection which initiated the pairing, the default agent remains
+// registered throughout the call, and is used for all other authentication requests. Since the agent's
+// authentication state is shared, only one such call can be in progress at a time, and it cannot be
+// made while any other pairing attempt is in progress, in which case [errorkinds.ErrPairingInProgress]
+// is returned.
+func (b *agent) withCapability(capability bluetooth.AgentCapability, fn func(conn *dbus.Conn) error) error {
+	if !b.initialized {
+		return errors.New("agent is not initialized")
+	}
+
+	if capability == agentCapability {
+		return b.withDefaultCapability(fn)
+	}
+
+	if !b.pairing.TryLock() {
+		return errorkinds.ErrPairingInProgress
+	}
+	defer b.pairing.Unlock()
+
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.Export(b, dbh.BluezAgentPath, dbh.BluezAgentIface); err != nil {
+		return err
+	}
+
+	if err := b.callAgentManager(conn, "RegisterAgent", dbh.BluezAgentPath, string(capability)).Store(); err != nil {
+		return err
+	}
+	defer b.unregister(conn)
+
+	return fn(conn)
+}
+
+// unregister unregisters the agent that was registered on the provided connection.
+func (b *agent) unregister(conn *dbus.Conn) {
+	if err := b.callAgentManager(conn, "UnregisterAgent", dbh.BluezAgentPath).Store(); err != nil {
+		dbh.PublishError(
+			b.store, err,
+			"Bluez agent error: Cannot unregister the agent capability",
+			"error_at", "agent-unregister-capability",
+		)
+	}
 }
 
 // remove removes the agent.
@@ -86,7 +165,7 @@ func (b *agent) remove() error {
 		return nil
 	}
 
-	return b.callAgentManager("UnregisterAgent", dbh.BluezAgentPath).Store()
+	return b.callAgentManager(b.systemBus, "UnregisterAgent", dbh.BluezAgentPath).Store()
 }
 
 // RequestPinCode returns a predefined pincode to the agent's pincode request.
@@ -104,7 +183,7 @@ func (b *agent) DisplayPinCode(devicePath dbus.ObjectPath, pincode string) *dbus
 	key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathDevice, devicePath)
 	if !ok {
 		dbh.PublishError(
-			errors.New(string(devicePath)),
+			b.store, errors.New(string(devicePath)),
 			"Bluez agent error: Device not found",
 			"error_at", "displaypin-device-address",
 		)
@@ -115,9 +194,16 @@ func (b *agent) DisplayPinCode(devicePath dbus.ObjectPath, pincode string) *dbus
 	b.ctx = bluetooth.NewAuthTimeout(b.authTimeout)
 	defer b.Cancel()
 
-	if err := b.authHandler.DisplayPinCode(b.ctx, pincode, key); err != nil {
+	err := b.authHandler.DisplayPinCode(b.ctx, pincode, key)
+	bluetooth.AuditAuthorization(b.authHandler, b.ctx, bluetooth.AuthAuditRecord{
+		Request:       bluetooth.AuthRequestDisplayPinCode,
+		DeviceAddress: key,
+		Pincode:       pincode,
+	}, err)
+
+	if err != nil {
 		dbh.PublishError(
-			err,
+			b.store, err,
 			"Bluez agent error: Authorization callback returned an error",
 			"error_at", "displaypin-device-address",
 		)
@@ -133,7 +219,7 @@ func (b *agent) DisplayPasskey(devicePath dbus.ObjectPath, passkey uint32, enter
 	key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathDevice, devicePath)
 	if !ok {
 		dbh.PublishError(
-			errors.New(string(devicePath)),
+			b.store, errors.New(string(devicePath)),
 			"Bluez agent error: Device not found",
 			"error_at", "displaypk-device-address",
 		)
@@ -141,12 +227,26 @@ func (b *agent) DisplayPasskey(devicePath dbus.ObjectPath, passkey uint32, enter
 		return dbus.MakeFailedError(errors.New("address not found"))
 	}
 
-	b.ctx = bluetooth.NewAuthTimeout(b.authTimeout)
-	defer b.Cancel()
+	// The passkey can be displayed multiple times during pairing, with an increasing
+	// number of entered digits. Since agent methods can be called concurrently, any stale
+	// updates are skipped, so that the authorizer only observes increasing values.
+	timeout, ok := b.displayPasskeyTimeout(key, entered)
+	if !ok {
+		return nil
+	}
+	defer timeout.Cancel()
+
+	err := b.authHandler.DisplayPasskey(timeout, passkey, entered, key)
+	bluetooth.AuditAuthorization(b.authHandler, timeout, bluetooth.AuthAuditRecord{
+		Request:       bluetooth.AuthRequestDisplayPasskey,
+		DeviceAddress: key,
+		Passkey:       passkey,
+		Entered:       entered,
+	}, err)
 
-	if err := b.authHandler.DisplayPasskey(b.ctx, passkey, entered, key); err != nil {
+	if err != nil {
 		dbh.PublishError(
-			err,
+			b.store, err,
 			"Bluez agent error: Authorization callback returned an error",
 			"error_at", "displaypk-device-address",
 		)
@@ -162,7 +262,7 @@ func (b *agent) RequestConfirmation(devicePath dbus.ObjectPath, passkey uint32)
 	key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathDevice, devicePath)
 	if !ok {
 		dbh.PublishError(
-			errors.New(string(devicePath)),
+			b.store, errors.New(string(devicePath)),
 			"Bluez agent error: Device not found",
 			"error_at", "authpk-device-address",
 		)
@@ -173,9 +273,16 @@ func (b *agent) RequestConfirmation(devicePath dbus.ObjectPath, passkey uint32)
 	b.ctx = bluetooth.NewAuthTimeout(b.authTimeout)
 	defer b.Cancel()
 
-	if err := b.authHandler.ConfirmPasskey(b.ctx, passkey, key); err != nil {
+	err := b.authHandler.ConfirmPasskey(b.ctx, passkey, key)
+	bluetooth.AuditAuthorization(b.authHandler, b.ctx, bluetooth.AuthAuditRecord{
+		Request:       bluetooth.AuthRequestConfirmPasskey,
+		DeviceAddress: key,
+		Passkey:       passkey,
+	}, err)
+
+	if err != nil {
 		dbh.PublishError(
-			err,
+			b.store, err,
 			"Bluez agent error: Authorization callback returned an error",
 			"error_at", "authpk-device-address",
 		)
@@ -191,7 +298,7 @@ func (b *agent) RequestAuthorization(devicePath dbus.ObjectPath) *dbus.Error {
 	key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathDevice, devicePath)
 	if !ok {
 		dbh.PublishError(
-			errors.New(string(devicePath)),
+			b.store, errors.New(string(devicePath)),
 			"Bluez agent error: Device not found",
 			"error_at", "authpairing-device-address",
 		)
@@ -202,9 +309,15 @@ func (b *agent) RequestAuthorization(devicePath dbus.ObjectPath) *dbus.Error {
 	b.ctx = bluetooth.NewAuthTimeout(b.authTimeout)
 	defer b.Cancel()
 
-	if err := b.authHandler.AuthorizePairing(b.ctx, key); err != nil {
+	err := b.authHandler.AuthorizePairing(b.ctx, key)
+	bluetooth.AuditAuthorization(b.authHandler, b.ctx, bluetooth.AuthAuditRecord{
+		Request:       bluetooth.AuthRequestAuthorizePairing,
+		DeviceAddress: key,
+	}, err)
+
+	if err != nil {
 		dbh.PublishError(
-			err,
+			b.store, err,
 			"Bluez agent error: Authorization callback returned an error",
 			"error_at", "authpairing-device-address",
 		)
@@ -220,7 +333,7 @@ func (b *agent) AuthorizeService(devicePath dbus.ObjectPath, uuidstr string) *db
 	key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathDevice, devicePath)
 	if !ok {
 		dbh.PublishError(
-			errors.New(string(devicePath)),
+			b.store, errors.New(string(devicePath)),
 			"Bluez agent error: Device not found",
 			"error_at", "authservice-device-address",
 		)
@@ -232,9 +345,16 @@ func (b *agent) AuthorizeService(devicePath dbus.ObjectPath, uuidstr string) *db
 	b.ctx = bluetooth.NewAuthTimeout(b.authTimeout)
 	defer b.Cancel()
 
-	if err := b.authHandler.AuthorizeService(b.ctx, u, key); err != nil {
+	err := b.authHandler.AuthorizeService(b.ctx, u, key)
+	bluetooth.AuditAuthorization(b.authHandler, b.ctx, bluetooth.AuthAuditRecord{
+		Request:       bluetooth.AuthRequestAuthorizeService,
+		DeviceAddress: key,
+		UUID:          u,
+	}, err)
+
+	if err != nil {
 		dbh.PublishError(
-			err,
+			b.store, err,
 			"Bluez agent error: Authorization callback returned an error",
 			"error_at", "authservice-device-address",
 		)
@@ -247,17 +367,38 @@ func (b *agent) AuthorizeService(devicePath dbus.ObjectPath, uuidstr string) *db
 
 // Cancel is called when the Bluez agent request was cancelled.
 func (b *agent) Cancel() *dbus.Error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	clear(b.passkeyEntered)
 	b.ctx.Cancel()
 
 	return nil
 }
 
+// displayPasskeyTimeout records the number of entered digits for a displayed passkey, and returns
+// a new authentication timeout. If a display with the same or a greater number of entered digits
+// was already recorded for the device, the update is stale, and false is returned.
+func (b *agent) displayPasskeyTimeout(key bluetooth.DeviceAddress, entered uint16) (bluetooth.AuthTimeout, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if previous, ok := b.passkeyEntered[key]; ok && entered != 0 && entered <= previous {
+		return bluetooth.AuthTimeout{}, false
+	}
+
+	b.passkeyEntered[key] = entered
+	b.ctx = bluetooth.NewAuthTimeout(b.authTimeout)
+
+	return b.ctx, true
+}
+
 // Release is called when the Bluez agent is unregistered.
 func (b *agent) Release() *dbus.Error {
 	return nil
 }
 
-// callAgentManager calls the AgentManager1 interface with the provided arguments.
-func (b *agent) callAgentManager(method string, args ...any) *dbus.Call {
-	return b.systemBus.Object(dbh.BluezBusName, dbh.BluezAgentManagerPath).Call(dbh.BluezAgentManagerIface+"."+method, 0, args...)
+// callAgentManager calls the AgentManager1 interface on the provided connection with the provided arguments.
+func (b *agent) callAgentManager(conn *dbus.Conn, method string, args ...any) *dbus.Call {
+	return conn.Object(dbh.BluezBusName, dbh.BluezAgentManagerPath).Call(dbh.BluezAgentManagerIface+"."+method, 0, args...)
 }
@@ -0,0 +1,141 @@
+//go:build linux
+
+package bluez
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
+	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
+	"github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbustest"
+	"github.com/godbus/dbus/v5"
+)
+
+// testAgentManager emulates the agent manager of Bluez, and records the agent registrations.
+// Agents which are registered on the default connection are recorded as "default", and
+// agents which are registered on any other connection are recorded as "other".
+type testAgentManager struct {
+	defaultConn string
+	calls       []string
+
+	mu sync.Mutex
+}
+
+func (m *testAgentManager) record(sender dbus.Sender, call string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	connection := "other"
+	if string(sender) == m.defaultConn {
+		connection = "default"
+	}
+
+	m.calls = append(m.calls, call+" "+connection)
+}
+
+func (m *testAgentManager) RegisterAgent(sender dbus.Sender, _ dbus.ObjectPath, capability string) *dbus.Error {
+	m.record(sender, "register "+capability)
+	return nil
+}
+
+func (m *testAgentManager) UnregisterAgent(sender dbus.Sender, _ dbus.ObjectPath) *dbus.Error {
+	m.record(sender, "unregister")
+	return nil
+}
+
+func (m *testAgentManager) RequestDefaultAgent(sender dbus.Sender, _ dbus.ObjectPath) *dbus.Error {
+	m.record(sender, "request-default")
+	return nil
+}
+
+// Calls returns the recorded calls, and clears them.
+func (m *testAgentManager) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := m.calls
+	m.calls = nil
+
+	return calls
+}
+
+func TestAgentCapabilityCycle(t *testing.T) {
+	conn := dbustest.NewSystemBus(t, dbh.BluezBusName)
+
+	manager := &testAgentManager{defaultConn: conn.Names()[0]}
+	if err := conn.Export(manager, dbh.BluezAgentManagerPath, dbh.BluezAgentManagerIface); err != nil {
+		t.Fatal(err)
+	}
+
+	store := sessionstore.NewSessionStore()
+
+	agent := newAgent(conn, &store, bluetooth.DefaultAuthorizer{}, time.Second)
+	if err := agent.setup(); err != nil {
+		t.Fatalf("setup() error = %v", err)
+	}
+
+	if got, want := manager.Calls(), []string{"register KeyboardDisplay default", "request-default default"}; !slices.Equal(got, want) {
+		t.Fatalf("agent manager calls after setup = %v, want %v", got, want)
+	}
+
+	for _, capability := range []bluetooth.AgentCapability{bluetooth.CapabilityNoInputNoOutput, bluetooth.CapabilityDisplayOnly} {
+		t.Run(string(capability), func(t *testing.T) {
+			var (
+				defaultPaired = make(chan struct{})
+				release       = make(chan struct{})
+			)
+
+			go func() {
+				<-release
+
+				// A plain pairing attempt waits for the capability pairing to complete,
+				// and then uses the default agent.
+				_ = agent.withDefaultCapability(func(pairConn *dbus.Conn) error {
+					if pairConn != conn {
+						t.Error("plain pairing does not use the default connection")
+					}
+
+					close(defaultPaired)
+
+					return nil
+				})
+			}()
+
+			err := agent.withCapability(capability, func(pairConn *dbus.Conn) error {
+				if pairConn == conn {
+					t.Error("capability pairing uses the default connection")
+				}
+
+				close(release)
+
+				select {
+				case <-defaultPaired:
+					t.Error("plain pairing was not blocked during the capability pairing")
+				case <-time.After(100 * time.Millisecond):
+				}
+
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("withCapability() error = %v", err)
+			}
+
+			select {
+			case <-defaultPaired:
+			case <-time.After(time.Second):
+				t.Fatal("plain pairing did not proceed after the capability pairing")
+			}
+
+			// The capability is registered on a separate connection and then unregistered,
+			// so that the default agent is restored without being registered again.
+			want := []string{"register " + string(capability) + " other", "unregister other"}
+			if got := manager.Calls(); !slices.Equal(got, want) {
+				t.Errorf("agent manager calls = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -4,12 +4,15 @@ package bluez
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/Southclaws/fault"
 	"github.com/Southclaws/fault/fctx"
 	"github.com/Southclaws/fault/fmsg"
 	"github.com/Southclaws/fault/ftag"
 	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/config"
 	errorkinds "github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 	"github.com/bluetuith-org/bluetooth-classic/api/optional"
 	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
@@ -27,11 +30,22 @@ type device struct {
 
 // Pair will attempt to pair a bluetooth device that is in pairing mode.
 func (d *device) Pair() error {
+	return d.pair(context.Background())
+}
+
+// pair attempts to pair with the device until the context (ctx) is done.
+// If the context does not have a deadline, the session's operation timeout is applied.
+func (d *device) pair(ctx context.Context) error {
 	if _, err := d.check(); err != nil {
 		return err
 	}
 
-	if err := d.callDevice("Pair", 0).Store(); err != nil {
+	ctx, cancel := config.WithOperationTimeout(ctx, d.b.operationTimeout)
+	defer cancel()
+
+	if err := d.b.agent.withDefaultCapability(func(conn *dbus.Conn) error {
+		return d.pairWith(ctx, conn)
+	}); err != nil {
 		return fault.Wrap(
 			err,
 			fctx.With(
@@ -74,13 +88,22 @@ func (d *device) CancelPairing() error {
 // Connect will attempt to connect an already paired bluetooth device
 // to an adapter.
 func (d *device) Connect() error {
+	return d.connect(context.Background())
+}
+
+// connect attempts to connect to the device until the context (ctx) is done.
+// If the context does not have a deadline, the session's operation timeout is applied.
+func (d *device) connect(ctx context.Context) error {
 	if _, err := d.check(); err != nil {
 		return err
 	}
 
-	if err := d.callDevice("Connect", 0).Store(); err != nil {
+	ctx, cancel := config.WithOperationTimeout(ctx, d.b.operationTimeout)
+	defer cancel()
+
+	if err := d.callDeviceWithContext(ctx, d.b.systemBus, "Connect"); err != nil {
 		return fault.Wrap(
-			err,
+			cancelError(err),
 			fctx.With(
 				context.Background(),
 				"error_at", "device-connect",
@@ -95,6 +118,63 @@ func (d *device) Connect() error {
 	return nil
 }
 
+// PairAndConnect will attempt to pair a bluetooth device, wait for its services
+// to be resolved, and then connect the device to the adapter.
+func (d *device) PairAndConnect(ctx context.Context) error {
+	if _, err := d.check(); err != nil {
+		return err
+	}
+
+	ctx, cancel := config.WithOperationTimeout(ctx, d.b.operationTimeout)
+	defer cancel()
+
+	return d.b.store.PairAndConnect(ctx, d.key, d.pair, d.connect)
+}
+
+// pairWith sends the pairing request to the device on the provided connection, so that the agent
+// which is registered on that connection handles the authentication requests of this pairing attempt.
+// If the context (ctx) is done before the pairing completes, the pairing attempt is cancelled.
+func (d *device) pairWith(ctx context.Context, conn *dbus.Conn) error {
+	err := d.callDeviceWithContext(ctx, conn, "Pair")
+	if err != nil && ctx.Err() != nil {
+		_ = d.callDevice("CancelPairing", 0).Store()
+	}
+
+	return cancelError(err)
+}
+
+// PairWithCapability will attempt to pair a bluetooth device, using the provided
+// authentication capability for this pairing attempt only. If the context does not have
+// a deadline, the session's operation timeout is applied.
+func (d *device) PairWithCapability(ctx context.Context, capability bluetooth.AgentCapability) error {
+	if _, err := d.check(); err != nil {
+		return err
+	}
+
+	ctx, cancel := config.WithOperationTimeout(ctx, d.b.operationTimeout)
+	defer cancel()
+
+	err := d.b.agent.withCapability(capability, func(conn *dbus.Conn) error {
+		return d.pairWith(ctx, conn)
+	})
+	if err != nil {
+		return fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "device-pair-capability",
+				"address", d.key.Address.String(),
+				"adapter", d.key.AssociatedAdapter.String(),
+				"capability", string(capability),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("Cannot pair with device"),
+		)
+	}
+
+	return nil
+}
+
 // Disconnect will disconnect the bluetooth device from the adapter.
 func (d *device) Disconnect() error {
 	if _, err := d.check(); err != nil {
@@ -125,7 +205,10 @@ func (d *device) ConnectProfile(profileUUID uuid.UUID) error {
 		return err
 	}
 
-	if err := d.callDevice("ConnectProfile", 0, profileUUID.String()).Store(); err != nil {
+	ctx, cancel := config.WithOperationTimeout(context.Background(), d.b.operationTimeout)
+	defer cancel()
+
+	if err := d.callDeviceWithContext(ctx, d.b.systemBus, "ConnectProfile", profileUUID.String()); err != nil {
 		return fault.Wrap(
 			err,
 			fctx.With(
@@ -258,6 +341,98 @@ func (d *device) Properties() (bluetooth.DeviceData, error) {
 	return d.check()
 }
 
+// SetProperty sets a raw property of the device.
+// This is an unstable API, which is meant to access properties that are not yet exposed
+// by the typed API. The property name and value type must match the Bluez Device1 interface.
+func (d *device) SetProperty(name string, value any) error {
+	if _, err := d.check(); err != nil {
+		return err
+	}
+
+	obj := d.b.systemBus.Object(dbh.BluezBusName, d.path)
+	if err := dbh.CheckProperty(obj, dbh.BluezDeviceIface, name, true); err != nil {
+		return fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "device-setproperty-check",
+				"property", name,
+				"address", d.key.Address.String(),
+				"adapter", d.key.AssociatedAdapter.String(),
+			),
+			ftag.With(ftag.InvalidArgument),
+			fmsg.With("Cannot set device property"),
+		)
+	}
+
+	if err := d.setDeviceProperty(d.path, name, value); err != nil {
+		return fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "device-setproperty",
+				"property", name,
+				"address", d.key.Address.String(),
+				"adapter", d.key.AssociatedAdapter.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("An error occurred on setting device property"),
+		)
+	}
+
+	return nil
+}
+
+// GetProperty gets a raw property of the device.
+// This is an unstable API, which is meant to access properties that are not yet exposed
+// by the typed API. The property name must match the Bluez Device1 interface.
+func (d *device) GetProperty(name string) (any, error) {
+	if _, err := d.check(); err != nil {
+		return nil, err
+	}
+
+	obj := d.b.systemBus.Object(dbh.BluezBusName, d.path)
+	if err := dbh.CheckProperty(obj, dbh.BluezDeviceIface, name, false); err != nil {
+		return nil, fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "device-getproperty-check",
+				"property", name,
+				"address", d.key.Address.String(),
+				"adapter", d.key.AssociatedAdapter.String(),
+			),
+			ftag.With(ftag.InvalidArgument),
+			fmsg.With("Cannot get device property"),
+		)
+	}
+
+	value, err := obj.GetProperty(dbh.BluezDeviceIface + "." + name)
+	if err != nil {
+		return nil, fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "device-getproperty",
+				"property", name,
+				"address", d.key.Address.String(),
+				"adapter", d.key.AssociatedAdapter.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("An error occurred on getting device property"),
+		)
+	}
+
+	return value.Value(), nil
+}
+
+// ConnectionParameters returns the negotiated connection parameters of the device.
+// Bluez does not expose the connection parameters via its DBus API, so this is
+// currently not supported.
+func (d *device) ConnectionParameters() (bluetooth.ConnParams, error) {
+	return bluetooth.ConnParams{}, errorkinds.ErrNotSupported
+}
+
 // check validates whether a valid DBus path is associated with the provided
 // device's address ((*Device).Address), and checks whether the device
 // properties are present within the global session store.
@@ -312,6 +487,28 @@ func (d *device) check() (bluetooth.DeviceData, error) {
 	return device, nil
 }
 
+// linkQuality samples the link quality of the device. Bluez only exposes the RSSI and the
+// transmit power of the device. Note that Bluez usually populates these properties only while
+// the device is discovered during a scan, so they are often absent for connected devices.
+func (d *device) linkQuality() (bluetooth.LinkQuality, error) {
+	quality := bluetooth.LinkQuality{Time: time.Now()}
+	object := d.b.systemBus.Object(dbh.BluezBusName, d.path)
+
+	if property, err := object.GetProperty(dbh.BluezDeviceIface + ".RSSI"); err == nil {
+		if rssi, ok := property.Value().(int16); ok {
+			quality.RSSI.Set(rssi)
+		}
+	}
+
+	if property, err := object.GetProperty(dbh.BluezDeviceIface + ".TxPower"); err == nil {
+		if txPower, ok := property.Value().(int16); ok {
+			quality.TxPower.Set(txPower)
+		}
+	}
+
+	return quality, nil
+}
+
 // callDevice is used to interact with the bluez Device dbus interface.
 // https://git.kernel.org/pub/scm/bluetooth/bluez.git/tree/doc/device-api.txt
 func (d *device) callDevice(method string, flags dbus.Flags, args ...any) *dbus.Call {
@@ -319,6 +516,52 @@ func (d *device) callDevice(method string, flags dbus.Flags, args ...any) *dbus.
 		Call(dbh.BluezDeviceIface+"."+method, flags, args...)
 }
 
+// callDeviceWithContext is used to interact with the bluez Device dbus interface on the provided connection,
+// for methods which can take some time to complete. The method call is cancelled once the context (ctx) is done,
+// and is not made at all if the context is already done.
+func (d *device) callDeviceWithContext(ctx context.Context, conn *dbus.Conn, method string, args ...any) error {
+	if ctx.Err() != nil {
+		return errorkinds.ContextError(ctx)
+	}
+
+	err := conn.Object(dbh.BluezBusName, d.path).
+		CallWithContext(ctx, dbh.BluezDeviceIface+"."+method, 0, args...).
+		Store()
+	if err != nil && ctx.Err() != nil {
+		return errorkinds.ContextError(ctx)
+	}
+
+	return err
+}
+
+// cancelError returns an error with the reason for the cancellation, if the error returned
+// by a pairing or connection attempt indicates that the attempt was cancelled.
+// Otherwise, the error is returned as is.
+func cancelError(err error) error {
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return err
+	}
+
+	var message string
+	if len(dbusErr.Body) > 0 {
+		message, _ = dbusErr.Body[0].(string)
+	}
+
+	switch {
+	case dbusErr.Name == "org.bluez.Error.AuthenticationCanceled",
+		message == "br-connection-canceled",
+		message == "br-connection-aborted-by-local",
+		message == "le-connection-abort-by-local":
+		return errorkinds.NewCancelError(errorkinds.CancelReasonUserAborted, err)
+
+	case message == "br-connection-aborted-by-remote":
+		return errorkinds.NewCancelError(errorkinds.CancelReasonPeerDisconnected, err)
+	}
+
+	return err
+}
+
 // setDeviceProperty can be used to set certain properties for a bluetooth device.
 func (d *device) setDeviceProperty(devicePath dbus.ObjectPath, key string, value any) error {
 	return d.b.systemBus.Object(dbh.BluezBusName, devicePath).Call(dbh.DbusSetPropertiesIface, 0, dbh.BluezDeviceIface, key, dbus.MakeVariant(value)).Store()
@@ -342,6 +585,7 @@ func (d *device) convertAndStoreObjects(values map[string]dbus.Variant) (bluetoo
 			Blocked => dbus.Variant{sig:dbus.Signature{str:"b"}, value:false}
 			Alias => dbus.Variant{sig:dbus.Signature{str:"s"}, value:"Bose QC35 II"}
 			Class => dbus.Variant{sig:dbus.Signature{str:"u"}, value:0x240418}
+			AdvertisingFlags => dbus.Variant{sig:dbus.Signature{str:"ay"}, value:[]uint8{0x1a}}
 
 	*/
 	device := struct {
@@ -397,6 +641,7 @@ func (d *device) convertAndStoreObjects(values map[string]dbus.Variant) (bluetoo
 
 	device.AssociatedAdapter = adapterMac
 	device.Type = bluetooth.DeviceTypeFromClass(device.Class)
+	device.Connectable = bluetooth.ConnectableFromAdvertisingFlags(device.AdvertisingFlags)
 
 	if p, err := d.batteryPercentage(); err == nil {
 		device.Percentage = optional.New(uint32(p))
@@ -412,6 +657,40 @@ func (d *device) convertAndStoreObjects(values map[string]dbus.Variant) (bluetoo
 	return device.DeviceData, nil
 }
 
+// refresh fetches the device properties, stores them within the session store,
+// and publishes a device update event.
+func (d *device) refresh() (bluetooth.DeviceData, error) {
+	if _, err := d.check(); err != nil {
+		return bluetooth.DeviceData{}, err
+	}
+
+	values := make(map[string]dbus.Variant)
+	if err := d.b.systemBus.Object(dbh.BluezBusName, d.path).
+		Call(dbh.DbusGetAllPropertiesIface, 0, dbh.BluezDeviceIface).
+		Store(&values); err != nil {
+		return bluetooth.DeviceData{}, fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "device-refresh-properties",
+				"address", d.key.Address.String(),
+				"adapter", d.key.AssociatedAdapter.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("Cannot fetch device properties"),
+		)
+	}
+
+	device, err := d.convertAndStoreObjects(values)
+	if err != nil {
+		return device, err
+	}
+
+	bluetooth.DeviceEvents().PublishUpdated(device.DeviceEventData)
+
+	return device, nil
+}
+
 // batteryPercentage gets the battery percentage of a device.
 func (d *device) batteryPercentage() (byte, error) {
 	var result byte
@@ -0,0 +1,70 @@
+//go:build linux
+
+package bluez
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/godbus/dbus/v5"
+)
+
+func TestCancelErrorMapping(t *testing.T) {
+	dbusErr := func(name, message string) error {
+		return dbus.Error{Name: name, Body: []any{message}}
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantReason errorkinds.CancelReason
+		wantCancel bool
+	}{
+		{"authentication cancelled", dbusErr("org.bluez.Error.AuthenticationCanceled", ""), errorkinds.CancelReasonUserAborted, true},
+		{"connection cancelled", dbusErr("org.bluez.Error.Failed", "br-connection-canceled"), errorkinds.CancelReasonUserAborted, true},
+		{"aborted by local", dbusErr("org.bluez.Error.Failed", "br-connection-aborted-by-local"), errorkinds.CancelReasonUserAborted, true},
+		{"le aborted by local", dbusErr("org.bluez.Error.Failed", "le-connection-abort-by-local"), errorkinds.CancelReasonUserAborted, true},
+		{"aborted by remote", dbusErr("org.bluez.Error.Failed", "br-connection-aborted-by-remote"), errorkinds.CancelReasonPeerDisconnected, true},
+		{"other dbus error", dbusErr("org.bluez.Error.Failed", "br-connection-page-timeout"), "", false},
+		{"dbus error without body", dbus.Error{Name: "org.bluez.Error.Failed"}, "", false},
+		{"non-dbus error", errors.New("failed"), "", false},
+		{"nil", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cancelError(tt.err)
+
+			reason, ok := errorkinds.CancelReasonOf(err)
+			if reason != tt.wantReason || ok != tt.wantCancel {
+				t.Errorf("CancelReasonOf() = (%q, %v), want (%q, %v)", reason, ok, tt.wantReason, tt.wantCancel)
+			}
+			if tt.err == nil {
+				if err != nil {
+					t.Errorf("cancelError(nil) = %v, want nil", err)
+				}
+
+				return
+			}
+
+			// DBus errors are not comparable, so they are matched by name.
+			var want, got dbus.Error
+			if errors.As(tt.err, &want) {
+				if !errors.As(err, &got) || got.Name != want.Name {
+					t.Errorf("cancelError() = %v, does not wrap %v", err, tt.err)
+				}
+			} else if !errors.Is(err, tt.err) {
+				t.Errorf("cancelError() = %v, does not wrap %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestConnectionParametersNotSupported(t *testing.T) {
+	params, err := (&device{}).ConnectionParameters()
+	if !errors.Is(err, errorkinds.ErrNotSupported) || params != (bluetooth.ConnParams{}) {
+		t.Errorf("ConnectionParameters() = (%+v, %v), want %v", params, err, errorkinds.ErrNotSupported)
+	}
+}
@@ -9,19 +9,21 @@ import (
 	"github.com/Southclaws/fault/fctx"
 	"github.com/Southclaws/fault/fmsg"
 	"github.com/Southclaws/fault/ftag"
-	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	errorkinds "github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	sstore "github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
 	"github.com/godbus/dbus/v5"
 )
 
-// PublishSignalError publishes an error message with DBus signal data to the error event stream.
-func PublishSignalError(err error, signal *dbus.Signal, message string, metadata ...string) {
-	bluetooth.ErrorEvents().PublishAdded(wrapSignalErrors(err, signal, message, metadata...))
+// PublishSignalError publishes an error message with DBus signal data to the error event stream,
+// and records the error within the error history of the session store.
+func PublishSignalError(store *sstore.SessionStore, err error, signal *dbus.Signal, message string, metadata ...string) {
+	store.ErrorEvents().PublishAdded(wrapSignalErrors(err, signal, message, metadata...))
 }
 
-// PublishError publishes an error to the error event stream.
-func PublishError(err error, message string, metadata ...string) {
-	bluetooth.ErrorEvents().PublishAdded(errorkinds.GenericError{
+// PublishError publishes an error to the error event stream, and records
+// the error within the error history of the session store.
+func PublishError(store *sstore.SessionStore, err error, message string, metadata ...string) {
+	store.ErrorEvents().PublishAdded(errorkinds.GenericError{
 		Errors: fault.Wrap(
 			err,
 			fctx.With(context.Background(), metadata...),
@@ -15,7 +15,7 @@ func PublishAdapterUpdateEvent(store *sstore.SessionStore, signal *dbus.Signal,
 		address, ok := PathConverter.AdapterAddress(signal.Path)
 		if !ok {
 			PublishSignalError(
-				errorkinds.ErrAdapterNotFound, signal,
+				store, errorkinds.ErrAdapterNotFound, signal,
 				"Bluez event handler error",
 				"error_at", "pchanged-adapter-address",
 			)
@@ -26,7 +26,7 @@ func PublishAdapterUpdateEvent(store *sstore.SessionStore, signal *dbus.Signal,
 		updated, err := store.UpdateAdapter(address, DecodeAdapterFunc(variants))
 		if err != nil {
 			PublishSignalError(
-				err, signal,
+				store, err, signal,
 				"Bluez event handler error",
 				"error_at", "pchanged-adapter-update",
 			)
@@ -44,7 +44,7 @@ func PublishDeviceUpdateEvent(store *sstore.SessionStore, signal *dbus.Signal, v
 		key, ok := PathConverter.DeviceAddress(DbusPathDevice, signal.Path)
 		if !ok {
 			PublishSignalError(
-				errorkinds.ErrDeviceNotFound, signal,
+				store, errorkinds.ErrDeviceNotFound, signal,
 				"Bluez event handler error",
 				"error_at", "pchanged-adapter-address",
 			)
@@ -55,7 +55,7 @@ func PublishDeviceUpdateEvent(store *sstore.SessionStore, signal *dbus.Signal, v
 		updated, err := store.UpdateDevice(key, DecodeDeviceFunc(variants))
 		if err != nil {
 			PublishSignalError(
-				err, signal,
+				store, err, signal,
 				"Bluez event handler error",
 				"error_at", "pchanged-adapter-update",
 			)
@@ -3,6 +3,8 @@
 package dbushelper
 
 import (
+	"slices"
+
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	"github.com/godbus/dbus/v5"
 	"github.com/puzpuzpuz/xsync/v3"
@@ -106,3 +108,22 @@ func (d *dbusPathConverter) DeviceDbusPath(pathType DbusDevicePathType, address
 
 	return dpath, dpath != ""
 }
+
+// DeviceDbusPaths returns all the Bluez DBus paths of the provided type that are mapped to the provided
+// Bluetooth address. This is used in cases where multiple paths of the same type can belong to a device,
+// for example, queued OBEX transfers.
+func (d *dbusPathConverter) DeviceDbusPaths(pathType DbusDevicePathType, address bluetooth.DeviceAddress) []dbus.ObjectPath {
+	var dpaths []dbus.ObjectPath
+
+	d.devicePaths.Range(func(p dbusPath, a bluetooth.DeviceAddress) bool {
+		if a == address && p.pathType == pathType {
+			dpaths = append(dpaths, p.path)
+		}
+
+		return true
+	})
+
+	slices.Sort(dpaths)
+
+	return dpaths
+}
@@ -2,7 +2,13 @@
 
 package dbushelper
 
-import "github.com/godbus/dbus/v5"
+import (
+	"slices"
+
+	errorkinds "github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+)
 
 // ListActivatableBusNames returns a list of bus names from the provided DBus connection.
 func ListActivatableBusNames(conn *dbus.Conn) ([]string, error) {
@@ -16,3 +22,35 @@ func ListActivatableBusNames(conn *dbus.Conn) ([]string, error) {
 
 	return names, nil
 }
+
+// CheckProperty validates whether a property with the provided name exists within the interface
+// of the object, and whether it can be written to (if 'write' is set). If the object cannot be
+// introspected, the property is assumed to be valid.
+func CheckProperty(obj dbus.BusObject, iface, name string, write bool) error {
+	node, err := introspect.Call(obj)
+	if err != nil {
+		return nil
+	}
+
+	index := slices.IndexFunc(node.Interfaces, func(i introspect.Interface) bool {
+		return i.Name == iface
+	})
+	if index < 0 {
+		return errorkinds.ErrPropertyNotFound
+	}
+
+	properties := node.Interfaces[index].Properties
+	index = slices.IndexFunc(properties, func(p introspect.Property) bool {
+		return p.Name == name
+	})
+
+	switch {
+	case index < 0:
+		return errorkinds.ErrPropertyNotFound
+
+	case write && properties[index].Access == "read":
+		return errorkinds.ErrPropertyReadOnly
+	}
+
+	return nil
+}
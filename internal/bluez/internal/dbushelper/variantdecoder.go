@@ -9,6 +9,8 @@ import (
 
 	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	sstore "github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+	"github.com/bluetuith-org/bluetooth-classic/internal/codecext"
 	"github.com/godbus/dbus/v5"
 	"github.com/ugorji/go/codec"
 )
@@ -56,6 +58,7 @@ func DecodeVariantMap(
 		handle.TypeInfos = codec.NewTypeInfos([]string{"codec"})
 		handle.SetInterfaceExt(reflect.TypeFor[dbus.Variant](), 1, variantExt{})
 		handle.SetInterfaceExt(reflect.TypeFor[*dbus.Variant](), 1, variantExt{})
+		handle.SetInterfaceExt(reflect.TypeFor[optional.Optional[string]](), 2, codecext.OptionalStringExt{})
 
 		variantDecoder.encoder = codec.NewEncoderBytes(&variantDecoder.data, &handle)
 		variantDecoder.decoder = codec.NewDecoderBytes(variantDecoder.data, &handle)
@@ -87,13 +90,59 @@ func DecodeVariantMap(
 // DecodeAdapterFunc returns a function to decode and merge adapter data.
 func DecodeAdapterFunc(variants map[string]dbus.Variant) sstore.MergeAdapterDataFunc {
 	return func(adapter *bluetooth.AdapterData) error {
-		return DecodeVariantMap(variants, adapter)
+		if err := DecodeVariantMap(variants, adapter); err != nil {
+			return err
+		}
+
+		DecodeAdapterPowerState(variants, adapter)
+
+		return nil
+	}
+}
+
+// DecodeAdapterPowerState sets the power state of the adapter from the 'PowerState' property,
+// which is provided by newer versions of Bluez. If it is absent, the power state is inferred
+// from the 'Powered' property.
+func DecodeAdapterPowerState(variants map[string]dbus.Variant, adapter *bluetooth.AdapterData) {
+	if v, ok := variants["PowerState"]; ok {
+		state, _ := v.Value().(string)
+
+		switch state {
+		case "on":
+			adapter.PowerState = bluetooth.PowerStateOn
+
+		case "off", "off-blocked":
+			adapter.PowerState = bluetooth.PowerStateOff
+
+		case "off-enabling":
+			adapter.PowerState = bluetooth.PowerStatePoweringOn
+
+		case "on-disabling":
+			adapter.PowerState = bluetooth.PowerStatePoweringOff
+
+		default:
+			adapter.PowerState = bluetooth.PowerStateUnknown
+		}
+
+		return
+	}
+
+	if _, ok := variants["Powered"]; ok {
+		adapter.PowerState = bluetooth.PowerStateFromPowered(adapter.Powered)
 	}
 }
 
 // DecodeDeviceFunc returns a function to decode and merge device data.
 func DecodeDeviceFunc(variants map[string]dbus.Variant) sstore.MergeDeviceDataFunc {
 	return func(device *bluetooth.DeviceData) error {
-		return DecodeVariantMap(variants, device)
+		if err := DecodeVariantMap(variants, device); err != nil {
+			return err
+		}
+
+		if _, ok := variants["AdvertisingFlags"]; ok {
+			device.Connectable = bluetooth.ConnectableFromAdvertisingFlags(device.AdvertisingFlags)
+		}
+
+		return nil
 	}
 }
@@ -0,0 +1,144 @@
+//go:build linux
+
+package dbushelper
+
+import (
+	"testing"
+
+	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	sstore "github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+	"github.com/godbus/dbus/v5"
+)
+
+func TestDecodeAdapterFuncMultipleProperties(t *testing.T) {
+	address := bluetooth.AdapterAddress{Address: bluetooth.MacAddress{1, 2, 3, 4, 5, 6}}
+
+	store := sstore.NewSessionStore()
+	store.AddAdapter(bluetooth.AdapterData{
+		AdapterEventData: bluetooth.AdapterEventData{
+			AdapterAddress: address,
+			Alias:          optional.New("old-alias"),
+			Class:          optional.New(uint32(0x100)),
+			Powered:        optional.New(false),
+			Discoverable:   optional.New(false),
+			Pairable:       optional.New(false),
+			Discovering:    optional.New(false),
+		},
+	})
+
+	updated, err := store.UpdateAdapter(address, DecodeAdapterFunc(map[string]dbus.Variant{
+		"Alias":        dbus.MakeVariant("new-alias"),
+		"Class":        dbus.MakeVariant(uint32(0x5a020c)),
+		"Powered":      dbus.MakeVariant(true),
+		"Discoverable": dbus.MakeVariant(true),
+		"Pairable":     dbus.MakeVariant(true),
+		"Discovering":  dbus.MakeVariant(true),
+	}))
+	if err != nil {
+		t.Fatalf("UpdateAdapter() error = %v", err)
+	}
+
+	stored, err := store.Adapter(address)
+	if err != nil {
+		t.Fatalf("Adapter() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		got, want any
+	}{
+		{"alias", stored.Alias, optional.New("new-alias")},
+		{"class", stored.Class, optional.New(uint32(0x5a020c))},
+		{"powered", stored.Powered, optional.New(true)},
+		{"discoverable", stored.Discoverable, optional.New(true)},
+		{"pairable", stored.Pairable, optional.New(true)},
+		{"discovering", stored.Discovering, optional.New(true)},
+		{"power state", stored.PowerState, bluetooth.PowerStateOn},
+	}
+
+	if updated.Alias != stored.Alias || updated.Class != stored.Class {
+		t.Errorf("published event %+v does not match the stored adapter %+v", updated, stored.AdapterEventData)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeAdapterPowerStateSequence(t *testing.T) {
+	powerState := func(state string) map[string]dbus.Variant {
+		return map[string]dbus.Variant{"PowerState": dbus.MakeVariant(state)}
+	}
+	powered := func(enabled bool) map[string]dbus.Variant {
+		return map[string]dbus.Variant{"Powered": dbus.MakeVariant(enabled)}
+	}
+
+	tests := []struct {
+		name    string
+		updates []map[string]dbus.Variant
+		want    []bluetooth.AdapterPowerState
+	}{
+		{
+			name:    "power state property",
+			updates: []map[string]dbus.Variant{powerState("off"), powerState("off-enabling"), powerState("on"), powerState("on-disabling"), powerState("off")},
+			want: []bluetooth.AdapterPowerState{
+				bluetooth.PowerStateOff, bluetooth.PowerStatePoweringOn, bluetooth.PowerStateOn,
+				bluetooth.PowerStatePoweringOff, bluetooth.PowerStateOff,
+			},
+		},
+		{
+			name:    "blocked adapter",
+			updates: []map[string]dbus.Variant{powerState("off-blocked"), powerState("off-enabling"), powerState("off-blocked")},
+			want:    []bluetooth.AdapterPowerState{bluetooth.PowerStateOff, bluetooth.PowerStatePoweringOn, bluetooth.PowerStateOff},
+		},
+		{
+			name:    "powered property only",
+			updates: []map[string]dbus.Variant{powered(false), powered(true), powered(false)},
+			want:    []bluetooth.AdapterPowerState{bluetooth.PowerStateOff, bluetooth.PowerStateOn, bluetooth.PowerStateOff},
+		},
+		{
+			name: "unrelated property keeps the power state",
+			updates: []map[string]dbus.Variant{
+				powerState("off-enabling"),
+				{"Alias": dbus.MakeVariant("alias")},
+				{"Powered": dbus.MakeVariant(true), "PowerState": dbus.MakeVariant("on")},
+			},
+			want: []bluetooth.AdapterPowerState{bluetooth.PowerStatePoweringOn, bluetooth.PowerStatePoweringOn, bluetooth.PowerStateOn},
+		},
+		{
+			name:    "unknown power state",
+			updates: []map[string]dbus.Variant{powerState("on"), powerState("on-resetting")},
+			want:    []bluetooth.AdapterPowerState{bluetooth.PowerStateOn, bluetooth.PowerStateUnknown},
+		},
+	}
+
+	address := bluetooth.AdapterAddress{Address: bluetooth.MacAddress{1, 2, 3, 4, 5, 6}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := sstore.NewSessionStore()
+			store.AddAdapter(bluetooth.AdapterData{
+				AdapterEventData: bluetooth.AdapterEventData{
+					AdapterAddress: address,
+					PowerState:     bluetooth.PowerStateUnknown,
+				},
+			})
+
+			for i, update := range tt.updates {
+				updated, err := store.UpdateAdapter(address, DecodeAdapterFunc(update))
+				if err != nil {
+					t.Fatalf("UpdateAdapter() error = %v", err)
+				}
+
+				if updated.PowerState != tt.want[i] {
+					t.Errorf("update %d: power state = %s, want %s", i, updated.PowerState, tt.want[i])
+				}
+			}
+		})
+	}
+}
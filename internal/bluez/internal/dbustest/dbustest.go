@@ -0,0 +1,106 @@
+//go:build linux
+
+package dbustest
+
+import (
+	"bufio"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// NewBus starts a private message bus, and returns a connection to it which owns the
+// provided bus name. The bus is stopped once the test completes. The test is skipped
+// if the message bus daemon cannot be started.
+func NewBus(t *testing.T, name string) *dbus.Conn {
+	t.Helper()
+
+	return connect(t, startBus(t), name)
+}
+
+// NewSystemBus starts a private message bus in the same way as [NewBus], and sets it as the
+// system bus until the test completes, so that new connections to the system bus connect to it.
+func NewSystemBus(t *testing.T, name string) *dbus.Conn {
+	t.Helper()
+
+	address := startBus(t)
+	t.Setenv("DBUS_SYSTEM_BUS_ADDRESS", address)
+
+	return connect(t, address, name)
+}
+
+// startBus starts a private message bus, and returns its address.
+func startBus(t *testing.T) string {
+	t.Helper()
+
+	daemon := exec.Command("dbus-daemon", "--session", "--nofork", "--print-address=1")
+
+	stdout, err := daemon.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := daemon.Start(); err != nil {
+		t.Skipf("cannot start a message bus: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = daemon.Process.Kill()
+		_ = daemon.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Skipf("cannot obtain the message bus address: %v", err)
+	}
+
+	return strings.TrimSpace(address)
+}
+
+// connect returns a connection to the message bus at the address, which owns the provided bus name.
+func connect(t *testing.T, address, name string) *dbus.Conn {
+	t.Helper()
+
+	conn, err := dbus.Connect(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if _, err := conn.RequestName(name, dbus.NameFlagDoNotQueue); err != nil {
+		t.Fatal(err)
+	}
+
+	return conn
+}
+
+// Properties emulates the properties interface of an exported object.
+// The interface names are mapped to the properties of each interface.
+type Properties map[string]map[string]any
+
+// Export exports the properties interface at the provided path.
+func (p Properties) Export(conn *dbus.Conn, path dbus.ObjectPath) error {
+	return conn.ExportMethodTable(map[string]any{
+		"Get":    p.get,
+		"GetAll": p.getAll,
+	}, path, "org.freedesktop.DBus.Properties")
+}
+
+func (p Properties) get(iface, name string) (dbus.Variant, *dbus.Error) {
+	value, ok := p[iface][name]
+	if !ok {
+		return dbus.Variant{}, dbus.NewError("org.freedesktop.DBus.Error.InvalidArgs", []any{"No such property " + name})
+	}
+
+	return dbus.MakeVariant(value), nil
+}
+
+func (p Properties) getAll(iface string) (map[string]dbus.Variant, *dbus.Error) {
+	props := make(map[string]dbus.Variant, len(p[iface]))
+	for name, value := range p[iface] {
+		props[name] = dbus.MakeVariant(value)
+	}
+
+	return props, nil
+}
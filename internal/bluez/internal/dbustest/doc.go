@@ -0,0 +1,7 @@
+/*
+Package dbustest provides helpers to test the Bluez DBus session against
+a private message bus, on which the Bluez DBus objects can be emulated.
+
+It is meant to be used only by tests.
+*/
+package dbustest
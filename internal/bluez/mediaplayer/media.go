@@ -10,6 +10,7 @@ import (
 	"github.com/Southclaws/fault/fctx"
 	"github.com/Southclaws/fault/fmsg"
 	"github.com/Southclaws/fault/ftag"
+	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
 	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	errorkinds "github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
@@ -366,6 +367,56 @@ func (m *MediaPlayer) Properties() (bluetooth.MediaData, error) {
 	return properties, nil
 }
 
+// Features returns the media features supported by the device. If the device provides a media control
+// interface (MediaControl1) without a media player interface (MediaPlayer1), for example if the device
+// supports only basic remote control, the media player feature is marked as degraded.
+func (m *MediaPlayer) Features() (*ac.FeatureSet, error) {
+	devicePath, ok := dbh.PathConverter.DeviceDbusPath(dbh.DbusPathDevice, m.Key)
+	if !ok {
+		return nil, fault.Wrap(
+			errorkinds.ErrDeviceNotFound,
+			fctx.With(
+				context.Background(),
+				"error_at", "media-features-device",
+				"address", m.Key.Address.String(),
+				"adapter", m.Key.AssociatedAdapter.String(),
+			),
+			ftag.With(ftag.NotFound),
+			fmsg.With("Device does not exist"),
+		)
+	}
+
+	mediaControl, err := m.mediaControlProperties(devicePath)
+	if err != nil {
+		var ce ac.Errors
+		ce.Append(ac.NewError(ac.FeatureMediaPlayer, errorkinds.ErrNotSupported))
+
+		return ac.NewFeatureSet(ac.FeatureNone, ce), nil
+	}
+
+	hasPlayer := false
+	if playerPath, ok := mediaControl["Player"].Value().(dbus.ObjectPath); ok && playerPath.IsValid() && playerPath != "/" {
+		_, err := m.mediaPlayerProperties(playerPath)
+		hasPlayer = err == nil
+	}
+
+	return MediaFeatures(hasPlayer), nil
+}
+
+// MediaFeatures returns the media features of a device which provides a media control
+// interface, depending on whether it also provides a media player interface (hasPlayer).
+func MediaFeatures(hasPlayer bool) *ac.FeatureSet {
+	features := ac.NewFeatureSet(ac.FeatureMediaPlayer, ac.Errors{})
+	if !hasPlayer {
+		features.Degrade(
+			ac.FeatureMediaPlayer,
+			"The device supports only basic media control, track and playback information is unavailable",
+		)
+	}
+
+	return features
+}
+
 // ParseMap parses a variant map of mediaplayer properties.
 func (m *MediaPlayer) ParseMap(values map[string]dbus.Variant) (bluetooth.MediaData, error) {
 	var props bluetooth.MediaData
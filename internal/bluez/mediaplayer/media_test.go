@@ -0,0 +1,34 @@
+//go:build linux
+
+package mediaplayer
+
+import (
+	"testing"
+
+	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
+)
+
+func TestMediaFeatures(t *testing.T) {
+	tests := []struct {
+		name      string
+		hasPlayer bool
+		want      ac.FeatureState
+	}{
+		{"media control only", false, ac.FeatureStateDegraded},
+		{"media control and player", true, ac.FeatureStateAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			features := MediaFeatures(tt.hasPlayer)
+			if got := features.State(ac.FeatureMediaPlayer); got != tt.want {
+				t.Errorf("State(FeatureMediaPlayer) = %s, want %s", got, tt.want)
+			}
+
+			degraded, _ := features.Degraded.Exists()
+			if _, ok := degraded[ac.FeatureMediaPlayer]; ok != (tt.want == ac.FeatureStateDegraded) {
+				t.Errorf("degradation reason present = %v", ok)
+			}
+		})
+	}
+}
@@ -16,7 +16,7 @@ import (
 // agent describes an OBEX agent connection.
 // Note that, all public methods are exported to the Obex Agent Manager
 // via the session bus, and hence is called by the Agent Manager only.
-// Any errors are published to the global error event stream.
+// Any errors are published to the error event stream of the session.
 type agent struct {
 	authHandler bluetooth.AuthorizeReceiveFile
 
@@ -99,7 +99,7 @@ func (o *agent) AuthorizePush(transferPath dbus.ObjectPath) (string, *dbus.Error
 	sessionProperty, err := o.sessionProperties(sessionPath)
 	if err != nil {
 		dbh.PublishError(
-			err,
+			o.Store, err,
 			"OBEX agent error: Could not get session properties",
 			"error_at", "authpush-session-properties",
 		)
@@ -110,7 +110,7 @@ func (o *agent) AuthorizePush(transferPath dbus.ObjectPath) (string, *dbus.Error
 	transferProperty, err := o.transferProperties(transferPath)
 	if err != nil {
 		dbh.PublishError(
-			err,
+			o.Store, err,
 			"OBEX agent error: Could not get transfer properties",
 			"error_at", "authpush-transfer-properties",
 		)
@@ -120,7 +120,7 @@ func (o *agent) AuthorizePush(transferPath dbus.ObjectPath) (string, *dbus.Error
 
 	if sessionProperty.Root == "" {
 		dbh.PublishError(
-			err,
+			o.Store, err,
 			"OBEX agent error: Session properties are empty",
 			"error_at", "authpush-session-rootdest",
 		)
@@ -130,7 +130,7 @@ func (o *agent) AuthorizePush(transferPath dbus.ObjectPath) (string, *dbus.Error
 
 	if transferProperty.Status == bluetooth.TransferError {
 		dbh.PublishError(
-			err,
+			o.Store, err,
 			"OBEX agent error: Transfer property is empty",
 			"error_at", "authpush-transfer-status",
 		)
@@ -138,7 +138,7 @@ func (o *agent) AuthorizePush(transferPath dbus.ObjectPath) (string, *dbus.Error
 		return "", o.makeError()
 	}
 
-	key := bluetooth.NewDeviceAddress(sessionProperty.Destination, sessionProperty.Source)
+	key := sessionProperty.deviceAddress()
 
 	bluetooth.ObjectPushEvents().PublishAdded(transferProperty.appendExtra(transferPath, key, struct{}{}).ObjectPushData)
 
@@ -146,9 +146,16 @@ func (o *agent) AuthorizePush(transferPath dbus.ObjectPath) (string, *dbus.Error
 	o.ctx = bluetooth.NewAuthTimeout(o.authTimeout)
 	defer o.Cancel()
 
-	if err := o.authHandler.AuthorizeTransfer(o.ctx, transferProperty.ObjectPushData); err != nil {
+	err = o.authHandler.AuthorizeTransfer(o.ctx, transferProperty.ObjectPushData)
+	bluetooth.AuditAuthorization(o.authHandler, o.ctx, bluetooth.AuthAuditRecord{
+		Request:       bluetooth.AuthRequestAuthorizeTransfer,
+		DeviceAddress: key,
+		ObjectPush:    transferProperty.ObjectPushData,
+	}, err)
+
+	if err != nil {
 		dbh.PublishError(
-			err,
+			o.Store, err,
 			"OBEX agent error: Transfer was not authorized",
 			"error_at", "authpush-agent-authorize",
 		)
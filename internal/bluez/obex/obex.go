@@ -13,6 +13,7 @@ import (
 	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
 	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	errorkinds "github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
 	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
 )
 
@@ -20,6 +21,9 @@ import (
 type Obex struct {
 	SessionBus *dbus.Conn
 	Key        bluetooth.DeviceAddress
+	Store      *sessionstore.SessionStore
+
+	OperationTimeout time.Duration
 }
 
 // ObexManager holds an OBEX session and agent.
@@ -29,15 +33,18 @@ type ObexManager struct {
 	agent       *agent
 	initialized bool
 
+	timer *bluetooth.ObjectPushTimer
+
 	Obex
 }
 
 //revive:enable
 
-// NewManager returns a new ObexManager.
-func NewManager(SessionBus *dbus.Conn) *ObexManager {
+// NewManager returns a new ObexManager. The store is used to record the transfer statistics
+// of devices, and the errors which are published by the OBEX session.
+func NewManager(SessionBus *dbus.Conn, store *sessionstore.SessionStore) *ObexManager {
 	return &ObexManager{
-		nil, false, Obex{SessionBus: SessionBus},
+		nil, false, bluetooth.NewObjectPushTimer(), Obex{SessionBus: SessionBus, Store: store},
 	}
 }
 
@@ -49,6 +56,14 @@ type obexSessionProperties struct {
 	Destination bluetooth.MacAddress
 }
 
+// deviceAddress returns the address of the peer device of the session.
+// For both sending and receiving sessions, the 'Destination' property holds the
+// address of the peer device, and the 'Source' property holds the address of the
+// local adapter.
+func (s obexSessionProperties) deviceAddress() bluetooth.DeviceAddress {
+	return bluetooth.NewDeviceAddress(s.Destination, s.Source)
+}
+
 // obexTransferProperties holds the properties for a created Obex transfer.
 type obexTransferProperties struct {
 	bluetooth.ObjectPushData
@@ -90,7 +105,7 @@ SetupAgent:
 
 	capabilities = ac.FeatureSendFile
 
-	o.agent = newAgent(auth, authTimeout, &fileTransfer{Obex{SessionBus: o.SessionBus}})
+	o.agent = newAgent(auth, authTimeout, &fileTransfer{Obex{SessionBus: o.SessionBus, Store: o.Store}})
 	if err := o.agent.setup(); err != nil {
 		return capabilities,
 			ac.NewError(ac.FeatureReceiveFile, err)
@@ -114,7 +129,7 @@ func (o *ObexManager) Stop() error {
 // ObjectPush returns a function call interface to invoke device file transfer
 // related functions.
 func (o *Obex) ObjectPush() bluetooth.ObexObjectPush {
-	return &fileTransfer{Obex{SessionBus: o.SessionBus, Key: o.Key}}
+	return &fileTransfer{*o}
 }
 
 // watchObexSessionBus will register a signal and watch for events from the OBEX DBus interface.
@@ -158,13 +173,15 @@ func (o *ObexManager) parseSignalData(signal *dbus.Signal) {
 					continue
 				}
 
-				key := bluetooth.NewDeviceAddress(sessionProps.Destination, sessionProps.Source)
+				key := sessionProps.deviceAddress()
 
 				dbh.PathConverter.AddDeviceDbusPath(dbh.DbusPathObexSession, dbus.ObjectPath(props.SessionID), key)
 				dbh.PathConverter.AddDeviceDbusPath(dbh.DbusPathObexTransfer, dbus.ObjectPath(objectPath), key)
 
 				if props.Filename != "" {
 					props.appendExtra(objectPath, key)
+					o.timer.Track(&props.ObjectPushEventData)
+					o.Store.AddTransfer(props.ObjectPushData)
 					bluetooth.ObjectPushEvents().PublishAdded(props.ObjectPushData)
 				}
 			}
@@ -188,7 +205,7 @@ func (o *ObexManager) parseSignalData(signal *dbus.Signal) {
 
 			if !ok {
 				dbh.PublishSignalError(
-					errorkinds.ErrDeviceNotFound, signal,
+					o.Store, errorkinds.ErrDeviceNotFound, signal,
 					"Obex event handler error",
 					"error_at", "pchanged-obex-address",
 				)
@@ -204,7 +221,7 @@ func (o *ObexManager) parseSignalData(signal *dbus.Signal) {
 				"Status", "Transferred",
 			); err != nil {
 				dbh.PublishSignalError(
-					err, signal,
+					o.Store, err, signal,
 					"Obex event handler error",
 					"error_at", "pchanged-obex-decode",
 				)
@@ -212,6 +229,8 @@ func (o *ObexManager) parseSignalData(signal *dbus.Signal) {
 				return
 			}
 
+			o.timer.Track(&transferData.ObjectPushEventData)
+			o.Store.UpdateTransfer(transferData.ObjectPushEventData)
 			bluetooth.ObjectPushEvents().PublishUpdated(transferData.ObjectPushEventData)
 		}
 
@@ -235,7 +254,7 @@ func (o *ObexManager) parseSignalData(signal *dbus.Signal) {
 				key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathObexTransfer, objectPath)
 				if !ok {
 					dbh.PublishSignalError(
-						errorkinds.ErrDeviceNotFound, signal,
+						o.Store, errorkinds.ErrDeviceNotFound, signal,
 						"Obex event handler error",
 						"error_at", "premoved-obex-address",
 					)
@@ -245,6 +264,8 @@ func (o *ObexManager) parseSignalData(signal *dbus.Signal) {
 
 				var props obexTransferProperties
 				props.appendExtra(objectPath, bluetooth.DeviceAddress(key))
+				o.timer.Remove(&props.ObjectPushEventData)
+				o.Store.RemoveTransfer(props.ObjectPushEventData)
 
 				bluetooth.ObjectPushEvents().PublishRemoved(props.ObjectPushEventData)
 
@@ -254,6 +275,32 @@ func (o *ObexManager) parseSignalData(signal *dbus.Signal) {
 	}
 }
 
+// sessionArgs returns the arguments to the Client1 interface's 'CreateSession' method, for the provided
+// session options. If the target or source are not set within the options, the default target and the
+// adapter associated with the device are used respectively.
+func sessionArgs(options bluetooth.ObexSessionOptions, target bluetooth.ObexTarget, key bluetooth.DeviceAddress) map[string]any {
+	args := make(map[string]any, 3)
+
+	if options.Target != "" {
+		target = options.Target
+	}
+	args["Target"] = string(target)
+
+	switch {
+	case !options.Source.IsNil():
+		args["Source"] = options.Source.String()
+
+	case !key.AssociatedAdapter.IsNil():
+		args["Source"] = key.AssociatedAdapter.String()
+	}
+
+	if options.Channel != 0 {
+		args["Channel"] = options.Channel
+	}
+
+	return args
+}
+
 // callClient calls the Client1 interface with the provided method.
 func (o *Obex) callClient(method string, args ...any) *dbus.Call {
 	return o.SessionBus.Object(dbh.ObexBusName, dbh.ObexBusPath).
@@ -303,7 +350,11 @@ func (o *Obex) transferProperties(transferPath dbus.ObjectPath) (obexTransferPro
 		return obexTransferProperties{}, err
 	}
 
-	return *transferProperties.appendExtra(transferPath, bluetooth.DeviceAddress{}), dbh.DecodeVariantMap(props, &transferProperties)
+	if err := dbh.DecodeVariantMap(props, &transferProperties); err != nil {
+		return obexTransferProperties{}, err
+	}
+
+	return *transferProperties.appendExtra(transferPath, bluetooth.DeviceAddress{}), nil
 }
 
 // appendExtra appends extra properties to the transfer item.
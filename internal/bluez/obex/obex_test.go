@@ -0,0 +1,121 @@
+//go:build linux
+
+package obex
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
+	"github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbustest"
+	"github.com/godbus/dbus/v5"
+)
+
+func TestSessionDeviceAddress(t *testing.T) {
+	const (
+		adapter = "00:00:00:00:00:01"
+		peer    = "00:11:22:33:44:55"
+	)
+
+	tests := []struct {
+		name string
+		path dbus.ObjectPath
+	}{
+		{name: "sending", path: "/org/bluez/obex/client/session0"},
+		{name: "receiving", path: "/org/bluez/obex/server/session0"},
+	}
+
+	adapterAddress, err := bluetooth.ParseMAC(adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerAddress, err := bluetooth.ParseMAC(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := bluetooth.NewDeviceAddress(peerAddress, adapterAddress)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbustest.NewBus(t, dbh.ObexBusName)
+
+			if err := (dbustest.Properties{dbh.ObexSessionIface: {
+				"Source":      adapter,
+				"Destination": peer,
+				"Target":      "00001105-0000-1000-8000-00805f9b34fb",
+			}}).Export(conn, tt.path); err != nil {
+				t.Fatal(err)
+			}
+
+			props, err := (&Obex{SessionBus: conn}).sessionProperties(tt.path)
+			if err != nil {
+				t.Fatalf("sessionProperties() error = %v", err)
+			}
+
+			if got := props.deviceAddress(); got != want {
+				t.Errorf("deviceAddress() = %s (adapter %s), want %s (adapter %s)",
+					got.Address, got.AssociatedAdapter, want.Address, want.AssociatedAdapter)
+			}
+		})
+	}
+}
+
+func TestSessionArgs(t *testing.T) {
+	adapter := bluetooth.MacAddress{0, 0, 0, 0, 0, 1}
+	source := bluetooth.MacAddress{0, 0, 0, 0, 0, 2}
+
+	key := bluetooth.NewDeviceAddress(bluetooth.MacAddress{0, 0x11, 0x22, 0x33, 0x44, 0x55}, adapter)
+
+	tests := []struct {
+		name    string
+		options bluetooth.ObexSessionOptions
+		key     bluetooth.DeviceAddress
+		want    map[string]any
+	}{
+		{
+			name: "defaults",
+			key:  key,
+			want: map[string]any{"Target": "opp", "Source": adapter.String()},
+		},
+		{
+			name:    "target",
+			options: bluetooth.ObexSessionOptions{Target: bluetooth.ObexTargetFileTransfer},
+			key:     key,
+			want:    map[string]any{"Target": "ftp", "Source": adapter.String()},
+		},
+		{
+			name:    "source",
+			options: bluetooth.ObexSessionOptions{Source: source},
+			key:     key,
+			want:    map[string]any{"Target": "opp", "Source": source.String()},
+		},
+		{
+			name:    "channel",
+			options: bluetooth.ObexSessionOptions{Channel: 12},
+			key:     key,
+			want:    map[string]any{"Target": "opp", "Source": adapter.String(), "Channel": byte(12)},
+		},
+		{
+			name: "no associated adapter",
+			key:  bluetooth.DeviceAddress{Address: key.Address},
+			want: map[string]any{"Target": "opp"},
+		},
+		{
+			name:    "all options",
+			options: bluetooth.ObexSessionOptions{Target: bluetooth.ObexTargetPhonebook, Source: source, Channel: 19},
+			key:     key,
+			want:    map[string]any{"Target": "pbap", "Source": source.String(), "Channel": byte(19)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionArgs(tt.options, bluetooth.ObexTargetObjectPush, tt.key); !maps.Equal(got, tt.want) {
+				t.Errorf("sessionArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Southclaws/fault/fmsg"
 	"github.com/Southclaws/fault/ftag"
 	bluetooth "github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/config"
 	errorkinds "github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
 	"github.com/godbus/dbus/v5"
@@ -23,22 +24,38 @@ type fileTransfer struct {
 // CreateSession creates a new Obex session with a device.
 // The context (ctx) can be provided in case this function call
 // needs to be cancelled, since this function call can take some time
-// to complete.
-func (o *fileTransfer) CreateSession(ctx context.Context) error {
+// to complete. If the context does not have a deadline, the session's operation
+// timeout is applied.
+func (o *fileTransfer) CreateSession(ctx context.Context, options ...bluetooth.ObexSessionOptions) error {
 	if err := o.check(); err != nil {
 		return err
 	}
 
+	ctx, cancel := config.WithOperationTimeout(ctx, o.OperationTimeout)
+	defer cancel()
+
+	if ctx.Err() != nil {
+		return errorkinds.ContextError(ctx)
+	}
+
 	var sessionPath dbus.ObjectPath
 
-	args := make(map[string]any, 1)
-	args["Target"] = "opp"
+	var opts bluetooth.ObexSessionOptions
+	if options != nil {
+		opts = options[0]
+	}
+
+	args := sessionArgs(opts, bluetooth.ObexTargetObjectPush, o.Key)
 
-	session := o.callClientAsync(ctx, "CreateSession", o.Key.Address.String(), args)
+	// The method call is not bound to the context, so that if the context is done before
+	// the call completes, any session that Bluez creates afterwards can still be removed.
+	session := o.callClientAsync(context.Background(), "CreateSession", o.Key.Address.String(), args)
 	select {
 	case <-ctx.Done():
+		go o.removeCancelledSession(session)
+
 		return fault.Wrap(
-			context.Canceled,
+			errorkinds.ContextError(ctx),
 			fctx.With(
 				context.Background(),
 				"error_at", "obex-createsession-cancelled",
@@ -122,6 +139,26 @@ func (o *fileTransfer) RemoveSession() error {
 	return nil
 }
 
+// removeCancelledSession waits for a cancelled session creation call to complete, and
+// removes the session if it was created, so that a partially-created session is not leaked.
+func (o *fileTransfer) removeCancelledSession(session *dbus.Call) {
+	var sessionPath dbus.ObjectPath
+
+	call := <-session.Done
+	if call.Err != nil || call.Store(&sessionPath) != nil {
+		return
+	}
+
+	if err := o.callClient("RemoveSession", sessionPath).Store(); err != nil {
+		dbh.PublishError(
+			o.Store, err, "Cannot remove cancelled file transfer session",
+			"error_at", "obex-createsession-cleanup",
+			"address", o.Key.Address.String(),
+			"adapter", o.Key.AssociatedAdapter.String(),
+		)
+	}
+}
+
 // SendFile sends a file to the device. The 'filepath' must be a full path to the file.
 func (o *fileTransfer) SendFile(filepath string) (bluetooth.ObjectPushData, error) {
 	if err := o.check(); err != nil {
@@ -225,11 +262,22 @@ func (o *fileTransfer) CancelTransfer() error {
 
 // SuspendTransfer suspends the transfer.
 func (o *fileTransfer) SuspendTransfer() error {
+	return o.suspendTransfer("")
+}
+
+// SuspendTransferWithID suspends the transfer with the provided transfer ID.
+func (o *fileTransfer) SuspendTransferWithID(id bluetooth.ObjectPushTransferID) error {
+	return o.suspendTransfer(id)
+}
+
+// suspendTransfer suspends the transfer with the provided transfer ID. If the ID is empty,
+// the first transfer that is associated with the device is suspended.
+func (o *fileTransfer) suspendTransfer(id bluetooth.ObjectPushTransferID) error {
 	if err := o.check(); err != nil {
 		return err
 	}
 
-	transferPath, ok := dbh.PathConverter.DeviceDbusPath(dbh.DbusPathObexTransfer, o.Key)
+	transferPath, ok := o.transferPath(id)
 	if !ok {
 		return fault.Wrap(
 			errorkinds.ErrPropertyDataParse,
@@ -263,11 +311,22 @@ func (o *fileTransfer) SuspendTransfer() error {
 
 // ResumeTransfer resumes the transfer.
 func (o *fileTransfer) ResumeTransfer() error {
+	return o.resumeTransfer("")
+}
+
+// ResumeTransferWithID resumes the transfer with the provided transfer ID.
+func (o *fileTransfer) ResumeTransferWithID(id bluetooth.ObjectPushTransferID) error {
+	return o.resumeTransfer(id)
+}
+
+// resumeTransfer resumes the transfer with the provided transfer ID. If the ID is empty,
+// the first transfer that is associated with the device is resumed.
+func (o *fileTransfer) resumeTransfer(id bluetooth.ObjectPushTransferID) error {
 	if err := o.check(); err != nil {
 		return err
 	}
 
-	transferPath, ok := dbh.PathConverter.DeviceDbusPath(dbh.DbusPathObexTransfer, o.Key)
+	transferPath, ok := o.transferPath(id)
 	if !ok {
 		return fault.Wrap(
 			errorkinds.ErrPropertyDataParse,
@@ -299,6 +358,81 @@ func (o *fileTransfer) ResumeTransfer() error {
 	return nil
 }
 
+// CanResume returns whether the transfer with the provided transfer ID can be resumed.
+// If the ID is empty, the first transfer that is associated with the device is checked.
+func (o *fileTransfer) CanResume(id bluetooth.ObjectPushTransferID) (bool, error) {
+	if err := o.check(); err != nil {
+		return false, err
+	}
+
+	transferPath, ok := o.transferPath(id)
+	if !ok {
+		return false, fault.Wrap(
+			errorkinds.ErrPropertyDataParse,
+			fctx.With(
+				context.Background(),
+				"error_at", "obex-canresume-path",
+				"address", o.Key.Address.String(),
+				"adapter", o.Key.AssociatedAdapter.String(),
+			),
+			ftag.With(ftag.NotFound),
+			fmsg.With("Cannot obtain file transfer data"),
+		)
+	}
+
+	transfer, err := o.transferProperties(transferPath)
+	if err != nil {
+		return false, fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "obex-canresume-properties",
+				"address", o.Key.Address.String(),
+				"adapter", o.Key.AssociatedAdapter.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("Cannot obtain file transfer status"),
+		)
+	}
+
+	return transfer.Status.Resumable(), nil
+}
+
+// Transfers returns the IDs of all the transfers that are queued or active
+// within the session.
+func (o *fileTransfer) Transfers() ([]bluetooth.ObjectPushTransferID, error) {
+	if err := o.check(); err != nil {
+		return nil, err
+	}
+
+	paths := dbh.PathConverter.DeviceDbusPaths(dbh.DbusPathObexTransfer, o.Key)
+
+	transfers := make([]bluetooth.ObjectPushTransferID, 0, len(paths))
+	for _, path := range paths {
+		transfers = append(transfers, bluetooth.ObjectPushTransferID(path))
+	}
+
+	return transfers, nil
+}
+
+// transferPath returns the DBus path of the transfer with the provided transfer ID, if it
+// is associated with the device. If the ID is empty, the first transfer path that is associated
+// with the device is returned.
+func (o *fileTransfer) transferPath(id bluetooth.ObjectPushTransferID) (dbus.ObjectPath, bool) {
+	if id == "" {
+		return dbh.PathConverter.DeviceDbusPath(dbh.DbusPathObexTransfer, o.Key)
+	}
+
+	path := dbus.ObjectPath(id)
+
+	key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathObexTransfer, path)
+	if !ok || key != o.Key {
+		return "", false
+	}
+
+	return path, true
+}
+
 // check checks whether the SessionBus was initialized.
 func (o *fileTransfer) check() error {
 	if o.SessionBus == nil {
@@ -0,0 +1,210 @@
+//go:build linux
+
+package obex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
+	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
+	"github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbustest"
+	"github.com/godbus/dbus/v5"
+)
+
+// testTransfer emulates an OBEX transfer object of the 'obexd' daemon.
+type testTransfer struct {
+	status bluetooth.ObjectPushStatus
+	mu     sync.Mutex
+}
+
+func (t *testTransfer) Suspend() *dbus.Error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status = bluetooth.TransferSuspended
+
+	return nil
+}
+
+func (t *testTransfer) Resume() *dbus.Error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status = bluetooth.TransferActive
+
+	return nil
+}
+
+func (t *testTransfer) GetAll(string) (map[string]dbus.Variant, *dbus.Error) {
+	return map[string]dbus.Variant{"Status": dbus.MakeVariant(string(t.Status()))}, nil
+}
+
+func (t *testTransfer) Status() bluetooth.ObjectPushStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status
+}
+
+// testClient emulates the OBEX client object of the 'obexd' daemon. Sessions are created
+// only once the client is released, so that the caller can be cancelled before that.
+type testClient struct {
+	release chan struct{}
+	removed chan dbus.ObjectPath
+	fail    bool
+}
+
+func (c *testClient) CreateSession(string, map[string]dbus.Variant) (dbus.ObjectPath, *dbus.Error) {
+	<-c.release
+
+	if c.fail {
+		return "", dbus.MakeFailedError(errors.New("connection refused"))
+	}
+
+	return "/org/bluez/obex/client/session0", nil
+}
+
+func (c *testClient) RemoveSession(path dbus.ObjectPath) *dbus.Error {
+	c.removed <- path
+
+	return nil
+}
+
+func TestCreateSessionCancelled(t *testing.T) {
+	tests := []struct {
+		name        string
+		fail        bool
+		wantRemoved dbus.ObjectPath
+	}{
+		{name: "session created after cancellation", wantRemoved: "/org/bluez/obex/client/session0"},
+		{name: "session not created after cancellation", fail: true},
+	}
+
+	mac, err := bluetooth.ParseMAC("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := bluetooth.NewDeviceAddress(mac, mac)
+	devicePath := dbus.ObjectPath("/org/bluez/hci0/dev_00_11_22_33_44_55")
+
+	dbh.PathConverter.AddDeviceDbusPath(dbh.DbusPathDevice, devicePath, key)
+	t.Cleanup(func() { dbh.PathConverter.RemoveDeviceDbusPath(dbh.DbusPathDevice, devicePath) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbustest.NewBus(t, dbh.ObexBusName)
+
+			client := &testClient{release: make(chan struct{}), removed: make(chan dbus.ObjectPath, 1), fail: tt.fail}
+			if err := conn.Export(client, dbh.ObexBusPath, dbh.ObexClientIface); err != nil {
+				t.Fatal(err)
+			}
+
+			store := sessionstore.NewSessionStore()
+			objectPush := (&Obex{SessionBus: conn, Key: key, Store: &store}).ObjectPush()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			if err := objectPush.CreateSession(ctx); !errors.Is(err, context.Canceled) {
+				t.Fatalf("CreateSession() error = %v, want %v", err, context.Canceled)
+			}
+
+			close(client.release)
+
+			select {
+			case path := <-client.removed:
+				if path != tt.wantRemoved {
+					t.Errorf("removed session %q, want %q", path, tt.wantRemoved)
+				}
+
+			case <-time.After(time.Second):
+				if tt.wantRemoved != "" {
+					t.Errorf("session %q was not removed", tt.wantRemoved)
+				}
+			}
+
+			if path, ok := dbh.PathConverter.DeviceDbusPath(dbh.DbusPathObexSession, key); ok {
+				t.Errorf("cancelled session %q is tracked", path)
+			}
+		})
+	}
+}
+
+func TestSuspendTransferWithID(t *testing.T) {
+	conn := dbustest.NewBus(t, dbh.ObexBusName)
+
+	mac, err := bluetooth.ParseMAC("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := bluetooth.NewDeviceAddress(mac, mac)
+
+	devicePath := dbus.ObjectPath("/org/bluez/hci0/dev_00_11_22_33_44_55")
+	paths := []dbus.ObjectPath{
+		"/org/bluez/obex/client/session0/transfer0",
+		"/org/bluez/obex/client/session0/transfer1",
+	}
+
+	dbh.PathConverter.AddDeviceDbusPath(dbh.DbusPathDevice, devicePath, key)
+	t.Cleanup(func() { dbh.PathConverter.RemoveDeviceDbusPath(dbh.DbusPathDevice, devicePath) })
+
+	transfers := make(map[dbus.ObjectPath]*testTransfer, len(paths))
+	for _, path := range paths {
+		transfer := &testTransfer{status: bluetooth.TransferActive}
+		transfers[path] = transfer
+
+		if err := conn.ExportMethodTable(map[string]any{
+			"Suspend": transfer.Suspend,
+			"Resume":  transfer.Resume,
+		}, path, dbh.ObexTransferIface); err != nil {
+			t.Fatal(err)
+		}
+		if err := conn.ExportMethodTable(map[string]any{"GetAll": transfer.GetAll}, path, "org.freedesktop.DBus.Properties"); err != nil {
+			t.Fatal(err)
+		}
+
+		dbh.PathConverter.AddDeviceDbusPath(dbh.DbusPathObexTransfer, path, key)
+		t.Cleanup(func() { dbh.PathConverter.RemoveDeviceDbusPath(dbh.DbusPathObexTransfer, path) })
+	}
+
+	suspended, running := paths[0], paths[1]
+	objectPush := (&Obex{SessionBus: conn, Key: key}).ObjectPush()
+
+	if err := objectPush.SuspendTransferWithID(bluetooth.ObjectPushTransferID(suspended)); err != nil {
+		t.Fatalf("SuspendTransferWithID() error = %v", err)
+	}
+
+	if got := transfers[suspended].Status(); got != bluetooth.TransferSuspended {
+		t.Errorf("suspended transfer status = %s, want %s", got, bluetooth.TransferSuspended)
+	}
+	if got := transfers[running].Status(); got != bluetooth.TransferActive {
+		t.Errorf("other transfer status = %s, want %s", got, bluetooth.TransferActive)
+	}
+
+	for path, want := range map[dbus.ObjectPath]bool{suspended: true, running: false} {
+		if got, err := objectPush.CanResume(bluetooth.ObjectPushTransferID(path)); err != nil || got != want {
+			t.Errorf("CanResume(%s) = (%v, %v), want (%v, nil)", path, got, err, want)
+		}
+	}
+
+	if err := objectPush.ResumeTransferWithID(bluetooth.ObjectPushTransferID(suspended)); err != nil {
+		t.Fatalf("ResumeTransferWithID() error = %v", err)
+	}
+
+	for path, transfer := range transfers {
+		if got := transfer.Status(); got != bluetooth.TransferActive {
+			t.Errorf("transfer %s status = %s after resuming, want %s", path, got, bluetooth.TransferActive)
+		}
+	}
+
+	if err := objectPush.SuspendTransferWithID("/org/bluez/obex/client/session1/transfer0"); err == nil {
+		t.Error("SuspendTransferWithID() with an unknown transfer succeeded, want an error")
+	}
+}
@@ -0,0 +1,93 @@
+//go:build linux
+
+package bluez
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/platforminfo"
+	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
+	"github.com/godbus/dbus/v5"
+)
+
+// The vendor and product IDs that Bluez assigns to its adapters' device ID
+// by default, in which the version of Bluez is encoded.
+const (
+	bluezVendorID  = 0x1d6b
+	bluezProductID = 0x0246
+)
+
+// adapterPlatformInfo populates the platform information with the address, address type
+// and the supported transports of the default adapter, and the version of Bluez.
+// The default adapter is the adapter with the lowest index (for example, 'hci0').
+func (b *DbusSession) adapterPlatformInfo(platform *platforminfo.PlatformInfo) error {
+	adapters, err := b.store.Adapters()
+	if err != nil {
+		return err
+	}
+
+	adapter := slices.MinFunc(adapters, func(a, b bluetooth.AdapterData) int {
+		return cmp.Or(
+			cmp.Compare(len(a.UniqueName), len(b.UniqueName)),
+			cmp.Compare(a.UniqueName, b.UniqueName),
+		)
+	})
+
+	path, ok := dbh.PathConverter.AdapterDbusPath(adapter.AdapterAddress)
+	if !ok {
+		return fmt.Errorf("adapter path %q: %w", adapter.Address.String(), errorkinds.ErrAdapterNotFound)
+	}
+
+	var properties map[string]dbus.Variant
+	if err := b.systemBus.Object(dbh.BluezBusName, path).
+		Call(dbh.DbusGetAllPropertiesIface, 0, dbh.BluezAdapterIface).
+		Store(&properties); err != nil {
+		return err
+	}
+
+	platform.AdapterAddress = adapter.Address.String()
+
+	if addressType, ok := properties["AddressType"].Value().(string); ok {
+		platform.AdapterAddressType = addressType
+	}
+
+	// Bluez does not expose the supported transports directly. The class of device is
+	// only set for adapters which support BR/EDR, and the 'Roles' property only lists
+	// roles for adapters which support LE.
+	if class, ok := properties["Class"].Value().(uint32); ok && class != 0 {
+		platform.Transports = append(platform.Transports, platforminfo.TransportBREDR)
+	}
+	if roles, ok := properties["Roles"].Value().([]string); ok && len(roles) > 0 {
+		platform.Transports = append(platform.Transports, platforminfo.TransportLE)
+	}
+
+	if modalias, ok := properties["Modalias"].Value().(string); ok {
+		platform.StackVersion = bluezVersion(modalias)
+	}
+
+	return nil
+}
+
+// bluezVersion returns the version of Bluez from the adapter's modalias. Unless overridden
+// in the Bluez configuration, the modalias is of the form 'usb:v1D6Bp0246dXXXX', where the
+// device ID 'XXXX' holds the major version in its upper byte and the minor version in its
+// lower byte. If the version cannot be determined, an empty string is returned.
+func bluezVersion(modalias string) string {
+	var source string
+	var vendor, product, version uint16
+
+	if _, err := fmt.Sscanf(modalias, "%3s:v%04Xp%04Xd%04X", &source, &vendor, &product, &version); err != nil {
+		return ""
+	}
+
+	if vendor != bluezVendorID || product != bluezProductID {
+		return ""
+	}
+
+	return strconv.Itoa(int(version>>8)) + "." + strconv.Itoa(int(version&0xff))
+}
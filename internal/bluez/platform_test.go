@@ -0,0 +1,144 @@
+//go:build linux
+
+package bluez
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
+	"github.com/bluetuith-org/bluetooth-classic/api/platforminfo"
+	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
+	"github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbustest"
+	"github.com/godbus/dbus/v5"
+)
+
+func TestBluezVersion(t *testing.T) {
+	tests := []struct {
+		modalias string
+		want     string
+	}{
+		{"usb:v1D6Bp0246d0548", "5.72"},
+		{"usb:v1D6Bp0246d0540", "5.64"},
+		{"usb:v1D6Bp0246d0400", "4.0"},
+		{"bluetooth:v1D6Bp0246d0548", ""},
+		{"usb:v8087p0029d0001", ""},
+		{"usb:v1D6Bp0246", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modalias, func(t *testing.T) {
+			if got := bluezVersion(tt.modalias); got != tt.want {
+				t.Errorf("bluezVersion(%q) = %q, want %q", tt.modalias, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdapterPlatformInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties map[string]any
+		want       platforminfo.PlatformInfo
+	}{
+		{
+			name: "dual-mode adapter",
+			properties: map[string]any{
+				"AddressType": "public",
+				"Class":       uint32(0x7c010c),
+				"Roles":       []string{"central", "peripheral"},
+				"Modalias":    "usb:v1D6Bp0246d0548",
+			},
+			want: platforminfo.PlatformInfo{
+				StackVersion:       "5.72",
+				AdapterAddressType: "public",
+				Transports:         []string{platforminfo.TransportBREDR, platforminfo.TransportLE},
+			},
+		},
+		{
+			name: "classic-only adapter",
+			properties: map[string]any{
+				"AddressType": "public",
+				"Class":       uint32(0x7c010c),
+				"Modalias":    "usb:v1D6Bp0246d0540",
+			},
+			want: platforminfo.PlatformInfo{
+				StackVersion:       "5.64",
+				AdapterAddressType: "public",
+				Transports:         []string{platforminfo.TransportBREDR},
+			},
+		},
+		{
+			name: "LE-only adapter without version",
+			properties: map[string]any{
+				"AddressType": "random",
+				"Class":       uint32(0),
+				"Roles":       []string{"central"},
+				"Modalias":    "usb:v8087p0029d0001",
+			},
+			want: platforminfo.PlatformInfo{
+				AdapterAddressType: "random",
+				Transports:         []string{platforminfo.TransportLE},
+			},
+		},
+	}
+
+	// The default adapter is the adapter with the lowest index.
+	adapters := []struct {
+		path    dbus.ObjectPath
+		address bluetooth.AdapterAddress
+	}{
+		{"/org/bluez/hci10", bluetooth.NewAdapterAddress(bluetooth.MacAddress{0, 0, 0, 0, 0, 0x10})},
+		{"/org/bluez/hci0", bluetooth.NewAdapterAddress(bluetooth.MacAddress{0, 0, 0, 0, 0, 1})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbustest.NewBus(t, dbh.BluezBusName)
+			b := &DbusSession{systemBus: conn, store: sessionstore.NewSessionStore()}
+
+			for _, adapter := range adapters {
+				properties := dbustest.Properties{dbh.BluezAdapterIface: tt.properties}
+				if adapter.path != "/org/bluez/hci0" {
+					properties = dbustest.Properties{dbh.BluezAdapterIface: {"Modalias": "usb:v1D6Bp0246d0300"}}
+				}
+
+				if err := properties.Export(conn, adapter.path); err != nil {
+					t.Fatal(err)
+				}
+
+				dbh.PathConverter.AddAdapterDbusPath(adapter.path, adapter.address)
+				t.Cleanup(func() { dbh.PathConverter.RemoveAdapterDbusPath(adapter.path) })
+
+				b.store.AddAdapter(bluetooth.AdapterData{
+					AdapterEventData: bluetooth.AdapterEventData{AdapterAddress: adapter.address},
+					UniqueName:       string(adapter.path[len("/org/bluez/"):]),
+				})
+			}
+
+			var got platforminfo.PlatformInfo
+			if err := b.adapterPlatformInfo(&got); err != nil {
+				t.Fatalf("adapterPlatformInfo() error = %v", err)
+			}
+
+			want := tt.want
+			want.AdapterAddress = adapters[1].address.Address.String()
+
+			if got.StackVersion != want.StackVersion || got.AdapterAddress != want.AdapterAddress ||
+				got.AdapterAddressType != want.AdapterAddressType || !slices.Equal(got.Transports, want.Transports) {
+				t.Errorf("adapterPlatformInfo() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestAdapterPlatformInfoWithoutAdapters(t *testing.T) {
+	b := &DbusSession{store: sessionstore.NewSessionStore()}
+
+	var platform platforminfo.PlatformInfo
+	if err := b.adapterPlatformInfo(&platform); err == nil {
+		t.Errorf("adapterPlatformInfo() = %+v, want an error", platform)
+	}
+}
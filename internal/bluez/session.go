@@ -6,6 +6,9 @@ import (
 	"context"
 	"maps"
 	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"github.com/Southclaws/fault"
 	"github.com/Southclaws/fault/fctx"
@@ -22,6 +25,7 @@ import (
 	nm "github.com/bluetuith-org/bluetooth-classic/internal/bluez/networkmanager"
 	"github.com/bluetuith-org/bluetooth-classic/internal/bluez/obex"
 	"github.com/godbus/dbus/v5"
+	"github.com/google/uuid"
 )
 
 const implementation = "BlueZ"
@@ -36,6 +40,10 @@ type DbusSession struct {
 	obexman *obex.ObexManager
 
 	store sessionstore.SessionStore
+
+	formatter atomic.Pointer[bluetooth.AddressFormatter]
+
+	operationTimeout time.Duration
 }
 
 // Start attempts to initialize and start interfacing with the Bluez daemon via DBus.
@@ -46,6 +54,8 @@ func (b *DbusSession) Start(
 	var capabilities ac.Features
 	var ce ac.Errors
 
+	cfg = cfg.WithDefaults()
+
 	if authHandler == nil {
 		authHandler = &bluetooth.DefaultAuthorizer{}
 	}
@@ -81,7 +91,10 @@ func (b *DbusSession) Start(
 		systemBus:  systemBus,
 		sessionBus: sessionBus,
 		store:      sessionstore.NewSessionStore(),
+
+		operationTimeout: cfg.OperationTimeout,
 	}
+	b.store.SetErrorHistoryCapacity(cfg.ErrorHistoryCapacity)
 
 	if err := b.refreshStore(); err != nil {
 		return nil, platform,
@@ -93,7 +106,7 @@ func (b *DbusSession) Start(
 			)
 	}
 
-	b.agent = newAgent(systemBus, authHandler, cfg.AuthTimeout)
+	b.agent = newAgent(systemBus, &b.store, authHandler, cfg.AuthTimeout)
 	if err := b.agent.setup(); err != nil {
 		return nil, platform,
 			fault.Wrap(
@@ -104,13 +117,24 @@ func (b *DbusSession) Start(
 			)
 	}
 
+	if features, err := b.ExperimentalFeatures(); err == nil {
+		platform.ExperimentalFeatures = features
+	}
+	if err := b.adapterPlatformInfo(&platform); err != nil {
+		dbh.PublishError(
+			&b.store, err,
+			"Cannot get the platform information of the default adapter",
+			"error_at", "platform-adapter-info",
+		)
+	}
+
 	capabilities.Add(
 		ac.FeatureConnection,
 		ac.FeaturePairing,
 		ac.FeatureMediaPlayer,
 	)
 
-	b.obexman = obex.NewManager(sessionBus)
+	b.obexman = obex.NewManager(sessionBus, &b.store)
 	obexcap, cerr := b.obexman.Initialize(authHandler, cfg.AuthTimeout)
 	if cerr != nil {
 		ce.Append(cerr)
@@ -173,9 +197,21 @@ func (b *DbusSession) Device(address bluetooth.DeviceAddress) bluetooth.Device {
 	return &device{b: b, key: address}
 }
 
+// RefreshAdapter fetches the properties of the adapter from Bluez, updates the adapter
+// within the session store, and publishes an adapter update event.
+func (b *DbusSession) RefreshAdapter(address bluetooth.AdapterAddress) (bluetooth.AdapterData, error) {
+	return (&adapter{b: b, key: address}).refresh()
+}
+
+// RefreshDevice fetches the properties of the device from Bluez, updates the device
+// within the session store, and publishes a device update event.
+func (b *DbusSession) RefreshDevice(address bluetooth.DeviceAddress) (bluetooth.DeviceData, error) {
+	return (&device{b: b, key: address}).refresh()
+}
+
 // Obex returns a function call interface to invoke obex related functions.
 func (b *DbusSession) Obex(address bluetooth.DeviceAddress) bluetooth.Obex {
-	return &obex.Obex{SessionBus: b.sessionBus, Key: address}
+	return &obex.Obex{SessionBus: b.sessionBus, Key: address, Store: &b.store, OperationTimeout: b.operationTimeout}
 }
 
 // Network returns a function call interface to invoke network related functions.
@@ -188,6 +224,116 @@ func (b *DbusSession) MediaPlayer(address bluetooth.DeviceAddress) bluetooth.Med
 	return &mp.MediaPlayer{SystemBus: b.systemBus, Key: address}
 }
 
+// WatchServicesResolved waits for the services of the device to be resolved, and sends
+// the device's service UUIDs once via the returned channel.
+func (b *DbusSession) WatchServicesResolved(ctx context.Context, address bluetooth.DeviceAddress) (<-chan []uuid.UUID, func()) {
+	return b.store.WatchServicesResolved(ctx, address)
+}
+
+// WatchConnectedDevices sends the list of connected devices via the returned channel,
+// each time a device connects or disconnects.
+func (b *DbusSession) WatchConnectedDevices(ctx context.Context) (<-chan []bluetooth.DeviceData, func()) {
+	return b.store.WatchConnectedDevices(ctx)
+}
+
+// WatchLinkQuality samples the link quality of the connected device at each interval,
+// and sends each sample via the returned channel. Since Bluez usually omits the RSSI and
+// transmit power of connected devices, [errorkinds.ErrNotSupported] is often returned.
+func (b *DbusSession) WatchLinkQuality(ctx context.Context, address bluetooth.DeviceAddress, interval time.Duration) (<-chan bluetooth.LinkQuality, func(), error) {
+	d := &device{b: b, key: address}
+	if _, err := d.check(); err != nil {
+		return nil, func() {}, err
+	}
+
+	return b.store.WatchLinkQuality(ctx, address, interval, d.linkQuality)
+}
+
+// SetLowPowerMode enables or disables the low-power mode, which suspends the link quality sampling.
+func (b *DbusSession) SetLowPowerMode(enable bool) {
+	b.store.SetLowPowerMode(enable)
+}
+
+// LowPowerMode returns whether the low-power mode is enabled.
+func (b *DbusSession) LowPowerMode() bool {
+	return b.store.LowPowerMode()
+}
+
+// RecentErrors returns the 'n' most recently published errors of the session.
+func (b *DbusSession) RecentErrors(n int) []bluetooth.BluetoothError {
+	return b.store.RecentErrors(n)
+}
+
+// WatchRawEvents sends the raw event frames that are received from the server via the returned channel.
+// Currently is not supported.
+func (b *DbusSession) WatchRawEvents(_ context.Context) (<-chan bluetooth.RawEvent, func(), error) {
+	return nil, func() {}, errorkinds.ErrNotSupported
+}
+
+// TransferStats returns the aggregate statistics of the file transfers with the device.
+func (b *DbusSession) TransferStats(address bluetooth.DeviceAddress) (bluetooth.TransferStats, error) {
+	return b.store.TransferStats(address)
+}
+
+// ResetTransferStats resets the file transfer statistics of the device.
+func (b *DbusSession) ResetTransferStats(address bluetooth.DeviceAddress) error {
+	return b.store.ResetTransferStats(address)
+}
+
+// SupportsObexTarget returns whether the device advertises the service of the Obex target.
+func (b *DbusSession) SupportsObexTarget(address bluetooth.MacAddress, target bluetooth.ObexTarget) (bool, error) {
+	return b.store.SupportsObexTarget(address, target)
+}
+
+// AddressFormatter returns the formatter that is used to display Bluetooth addresses.
+func (b *DbusSession) AddressFormatter() bluetooth.AddressFormatter {
+	if formatter := b.formatter.Load(); formatter != nil {
+		return *formatter
+	}
+
+	return bluetooth.AddressFormatter{}
+}
+
+// SetAddressFormatter sets the formatter that is used to display Bluetooth addresses.
+func (b *DbusSession) SetAddressFormatter(formatter bluetooth.AddressFormatter) {
+	b.formatter.Store(&formatter)
+}
+
+// ExperimentalFeatures returns the identifiers of the experimental features that are enabled
+// within the Bluez daemon. Bluez exposes these as UUIDs via the 'ExperimentalFeatures' adapter
+// property, which is absent on older versions of the daemon.
+func (b *DbusSession) ExperimentalFeatures() ([]string, error) {
+	adapters, err := b.store.Adapters()
+	if err != nil {
+		return nil, err
+	}
+
+	features := []string{}
+
+	for _, adapter := range adapters {
+		path, ok := dbh.PathConverter.AdapterDbusPath(adapter.AdapterAddress)
+		if !ok {
+			continue
+		}
+
+		property, err := b.systemBus.Object(dbh.BluezBusName, path).
+			GetProperty(dbh.BluezAdapterIface + ".ExperimentalFeatures")
+		if err != nil {
+			continue
+		}
+
+		uuids, ok := property.Value().([]string)
+		if !ok {
+			continue
+		}
+
+		features = append(features, uuids...)
+	}
+
+	slices.Sort(features)
+
+	return slices.Compact(features), nil
+}
+
 // adapterInternal returns an adapter-related function call interface for internal use.
 // This is used primarily to initialize adapterInternal objects.
 func (b *DbusSession) adapterInternal(path dbus.ObjectPath) *adapter {
@@ -283,7 +429,7 @@ func (b *DbusSession) parseSignalData(signal *dbus.Signal) {
 			key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathDevice, devicePath)
 			if !ok {
 				dbh.PublishSignalError(
-					errorkinds.ErrDeviceNotFound, signal,
+					&b.store, errorkinds.ErrDeviceNotFound, signal,
 					"Bluez event handler error",
 					"error_at", "pchanged-mediaplayer-address",
 				)
@@ -294,7 +440,7 @@ func (b *DbusSession) parseSignalData(signal *dbus.Signal) {
 			properties, err := b.mediaPlayerInternal().ParseMap(propertyMap)
 			if err != nil {
 				dbh.PublishSignalError(
-					err, signal,
+					&b.store, err, signal,
 					"Bluez event handler error",
 					"error_at", "pchanged-mediaplayer-address",
 				)
@@ -317,7 +463,7 @@ func (b *DbusSession) parseSignalData(signal *dbus.Signal) {
 
 			if percentage < 0 {
 				dbh.PublishSignalError(
-					errorkinds.ErrEventDataParse, signal,
+					&b.store, errorkinds.ErrEventDataParse, signal,
 					"Bluez event handler error",
 					"error_at", "pchanged-batterypct-decode",
 				)
@@ -362,7 +508,7 @@ func (b *DbusSession) parseSignalData(signal *dbus.Signal) {
 				adapter, err := b.adapterInternal(objectPath).convertAndStoreObjects(mergedPropertyMap)
 				if err != nil {
 					dbh.PublishSignalError(
-						err, signal,
+						&b.store, err, signal,
 						"Bluez event handler error",
 						"error_at", "padded-adapter-decode",
 					)
@@ -379,7 +525,7 @@ func (b *DbusSession) parseSignalData(signal *dbus.Signal) {
 				device, err := b.deviceInternal(objectPath).convertAndStoreObjects(mergedPropertyMap)
 				if err != nil {
 					dbh.PublishSignalError(
-						err, signal,
+						&b.store, err, signal,
 						"Bluez event handler error",
 						"error_at", "padded-device-decode",
 					)
@@ -409,7 +555,7 @@ func (b *DbusSession) parseSignalData(signal *dbus.Signal) {
 
 				if percentage < 0 {
 					dbh.PublishSignalError(
-						errorkinds.ErrEventDataParse, signal,
+						&b.store, errorkinds.ErrEventDataParse, signal,
 						"Bluez event handler error",
 						"error_at", "padded-batterypct-decode",
 					)
@@ -443,7 +589,7 @@ func (b *DbusSession) parseSignalData(signal *dbus.Signal) {
 				key, ok := dbh.PathConverter.AdapterAddress(objectPath)
 				if !ok {
 					dbh.PublishSignalError(
-						errorkinds.ErrAdapterNotFound, signal,
+						&b.store, errorkinds.ErrAdapterNotFound, signal,
 						"Bluez event handler error",
 						"error_at", "premoved-adapter-address",
 					)
@@ -461,7 +607,7 @@ func (b *DbusSession) parseSignalData(signal *dbus.Signal) {
 				key, ok := dbh.PathConverter.DeviceAddress(dbh.DbusPathDevice, objectPath)
 				if !ok {
 					dbh.PublishSignalError(
-						errorkinds.ErrDeviceNotFound, signal,
+						&b.store, errorkinds.ErrDeviceNotFound, signal,
 						"Bluez event handler error",
 						"error_at", "premoved-device-address",
 					)
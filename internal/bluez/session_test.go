@@ -0,0 +1,200 @@
+//go:build linux
+
+package bluez
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+	dbh "github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbushelper"
+	"github.com/bluetuith-org/bluetooth-classic/internal/bluez/internal/dbustest"
+	"github.com/godbus/dbus/v5"
+)
+
+func TestExperimentalFeatures(t *testing.T) {
+	const (
+		isoSocket = "6fbaf188-05e0-496a-9885-d6ddfdb4e03e"
+		leAudio   = "a6695ace-ee7f-4fb9-881a-5fac66c629af"
+	)
+
+	tests := []struct {
+		name     string
+		adapters [][]string
+		want     []string
+		wantErr  bool
+	}{
+		{name: "no adapters", wantErr: true},
+		{name: "property absent", adapters: [][]string{nil}, want: []string{}},
+		{name: "no features enabled", adapters: [][]string{{}}, want: []string{}},
+		{name: "single adapter", adapters: [][]string{{leAudio, isoSocket}}, want: []string{isoSocket, leAudio}},
+		{name: "shared across adapters", adapters: [][]string{{isoSocket}, {leAudio, isoSocket}, nil}, want: []string{isoSocket, leAudio}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbustest.NewBus(t, dbh.BluezBusName)
+
+			b := &DbusSession{systemBus: conn, store: sessionstore.NewSessionStore()}
+
+			for i, features := range tt.adapters {
+				path := dbus.ObjectPath(fmt.Sprintf("/org/bluez/hci%d", i))
+				address := bluetooth.NewAdapterAddress(bluetooth.MacAddress{0, 0, 0, 0, 0, byte(i + 1)})
+
+				properties := dbustest.Properties{dbh.BluezAdapterIface: {}}
+				if features != nil {
+					properties[dbh.BluezAdapterIface]["ExperimentalFeatures"] = features
+				}
+				if err := properties.Export(conn, path); err != nil {
+					t.Fatal(err)
+				}
+
+				dbh.PathConverter.AddAdapterDbusPath(path, address)
+				t.Cleanup(func() { dbh.PathConverter.RemoveAdapterDbusPath(path) })
+
+				b.store.AddAdapter(bluetooth.AdapterData{AdapterEventData: bluetooth.AdapterEventData{AdapterAddress: address}})
+			}
+
+			got, err := b.ExperimentalFeatures()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ExperimentalFeatures() = %v, want an error", got)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExperimentalFeatures() error = %v", err)
+			}
+			if got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("ExperimentalFeatures() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshAdapter(t *testing.T) {
+	tests := []struct {
+		name     string
+		register bool
+		export   bool
+		wantErr  bool
+	}{
+		{name: "updated properties", register: true, export: true},
+		{name: "unknown adapter", wantErr: true},
+		{name: "properties unavailable", register: true, wantErr: true},
+	}
+
+	path := dbus.ObjectPath("/org/bluez/hci0")
+	address := bluetooth.NewAdapterAddress(bluetooth.MacAddress{0, 0, 0, 0, 0, 1})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbustest.NewBus(t, dbh.BluezBusName)
+
+			if tt.export {
+				if err := (dbustest.Properties{dbh.BluezAdapterIface: {
+					"Address": address.Address.String(),
+					"Alias":   "updated",
+					"Powered": true,
+				}}).Export(conn, path); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.register {
+				dbh.PathConverter.AddAdapterDbusPath(path, address)
+				t.Cleanup(func() { dbh.PathConverter.RemoveAdapterDbusPath(path) })
+			}
+
+			b := &DbusSession{systemBus: conn, store: sessionstore.NewSessionStore()}
+			b.store.AddAdapter(bluetooth.AdapterData{AdapterEventData: bluetooth.AdapterEventData{AdapterAddress: address, Alias: optional.New("stale")}})
+
+			got, err := b.RefreshAdapter(address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RefreshAdapter() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			want := "stale"
+			if !tt.wantErr {
+				want = "updated"
+				if got.Alias.Value() != want || !got.Powered.Value() {
+					t.Errorf("RefreshAdapter() = %+v, want the updated properties", got)
+				}
+			}
+
+			if stored, err := b.store.Adapter(address); err != nil || stored.Alias.Value() != want {
+				t.Errorf("stored adapter alias = %q (%v), want %q", stored.Alias.Value(), err, want)
+			}
+		})
+	}
+}
+
+func TestRefreshDevice(t *testing.T) {
+	tests := []struct {
+		name     string
+		register bool
+		export   bool
+		wantErr  bool
+	}{
+		{name: "updated properties", register: true, export: true},
+		{name: "unknown device", wantErr: true},
+		{name: "properties unavailable", register: true, wantErr: true},
+	}
+
+	adapterPath := dbus.ObjectPath("/org/bluez/hci0")
+	devicePath := dbus.ObjectPath("/org/bluez/hci0/dev_00_11_22_33_44_55")
+
+	adapter := bluetooth.MacAddress{0, 0, 0, 0, 0, 1}
+	address := bluetooth.NewDeviceAddress(bluetooth.MacAddress{0, 0x11, 0x22, 0x33, 0x44, 0x55}, adapter)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbustest.NewBus(t, dbh.BluezBusName)
+
+			if err := (dbustest.Properties{dbh.BluezAdapterIface: {"Address": adapter.String()}}).Export(conn, adapterPath); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.export {
+				if err := (dbustest.Properties{dbh.BluezDeviceIface: {
+					"Address":   address.Address.String(),
+					"Name":      "Headset",
+					"Alias":     "updated",
+					"Adapter":   adapterPath,
+					"Connected": true,
+				}}).Export(conn, devicePath); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.register {
+				dbh.PathConverter.AddDeviceDbusPath(dbh.DbusPathDevice, devicePath, address)
+				t.Cleanup(func() { dbh.PathConverter.RemoveDeviceDbusPath(dbh.DbusPathDevice, devicePath) })
+			}
+
+			b := &DbusSession{systemBus: conn, store: sessionstore.NewSessionStore()}
+			b.store.AddDevice(bluetooth.DeviceData{DeviceEventData: bluetooth.DeviceEventData{DeviceAddress: address, Alias: optional.New("stale")}})
+
+			got, err := b.RefreshDevice(address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RefreshDevice() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			want := "stale"
+			if !tt.wantErr {
+				want = "updated"
+				if got.Alias.Value() != want || !got.Connected.Value() || got.DeviceAddress != address {
+					t.Errorf("RefreshDevice() = %+v, want the updated properties", got)
+				}
+			}
+
+			if stored, err := b.store.Device(address); err != nil || stored.Alias.Value() != want {
+				t.Errorf("stored device alias = %q (%v), want %q", stored.Alias.Value(), err, want)
+			}
+		})
+	}
+}
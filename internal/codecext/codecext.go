@@ -0,0 +1,20 @@
+package codecext
+
+import "github.com/bluetuith-org/bluetooth-classic/api/optional"
+
+// OptionalStringExt represents a go-codec extension to parse optional string values.
+// The JSON (un)marshaler of the optional type encodes strings without quotes, so the
+// decoded string values are stored directly instead.
+type OptionalStringExt struct{}
+
+// ConvertExt converts an optional string into an encodable value.
+func (o OptionalStringExt) ConvertExt(value any) any {
+	return value.(*optional.Optional[string]).Value()
+}
+
+// UpdateExt decodes/updates an encoded value (src) to a new optional string (dst).
+func (o OptionalStringExt) UpdateExt(dst, src any) {
+	if str, ok := src.(string); ok {
+		dst.(*optional.Optional[string]).Set(str)
+	}
+}
@@ -0,0 +1,4 @@
+/*
+Package codecext provides go-codec extensions which are shared between the session backends.
+*/
+package codecext
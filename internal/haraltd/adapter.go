@@ -4,6 +4,7 @@ package haraltd
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Southclaws/fault"
 	"github.com/Southclaws/fault/fctx"
@@ -11,6 +12,7 @@ import (
 	"github.com/Southclaws/fault/ftag"
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
 	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/commands"
 )
 
@@ -71,12 +73,19 @@ func (a *adapter) StopDiscovery() error {
 
 // SetPoweredState sets the powered state of the adapter.
 func (a *adapter) SetPoweredState(enable bool) error {
-	if _, err := a.check(); err != nil {
+	adapter, err := a.check()
+	if err != nil {
 		return err
 	}
 
-	_, err := commands.SetPoweredState(a.key.Address, enable).ExecuteWith(a.s.executor)
+	if powered, ok := adapter.Powered.Get(); !ok || powered != enable {
+		a.publishPowerState(bluetooth.PowerStateTransition(enable))
+	}
+
+	_, err = commands.SetPoweredState(a.key.Address, enable).ExecuteWith(a.s.executor)
 	if err != nil {
+		a.publishPowerState(bluetooth.PowerStateFromPowered(adapter.Powered))
+
 		return fault.Wrap(
 			err,
 			fctx.With(
@@ -89,6 +98,8 @@ func (a *adapter) SetPoweredState(enable bool) error {
 		)
 	}
 
+	a.publishPowerState(bluetooth.PowerStateFromPowered(optional.New(enable)))
+
 	return nil
 }
 
@@ -138,6 +149,72 @@ func (a *adapter) SetPairableState(enable bool) error {
 	return nil
 }
 
+// RemoveAllDevices removes (unpairs and forgets) all the devices associated with the adapter.
+// The devices are removed by the server, which continues past individual failures and returns
+// all the errors that occurred together. If some devices could not be removed, the paired devices
+// are fetched again from the server, and only the devices which are no longer paired are removed
+// from the store. A removal event is published for each device which is removed from the store.
+func (a *adapter) RemoveAllDevices() error {
+	devices, err := a.Devices()
+	if err != nil {
+		return err
+	}
+
+	_, err = commands.RemoveAllDevices(a.key.Address).ExecuteWith(a.s.executor)
+	if err != nil {
+		remaining, fetchErr := commands.GetPairedDevices(a.key.Address).ExecuteWith(a.s.executor)
+		if fetchErr == nil {
+			paired := make(map[bluetooth.MacAddress]struct{}, len(remaining))
+			for _, device := range remaining {
+				paired[device.Address] = struct{}{}
+			}
+
+			for _, device := range devices {
+				if _, ok := paired[device.Address]; !ok {
+					a.removeDevice(device.DeviceEventData)
+				}
+			}
+		}
+
+		return fault.Wrap(
+			errors.Join(err, fetchErr),
+			fctx.With(
+				context.Background(),
+				"error_at", "adapter-remove-all-devices",
+				"address", a.key.Address.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("Some devices could not be removed"),
+		)
+	}
+
+	for _, device := range devices {
+		a.removeDevice(device.DeviceEventData)
+	}
+
+	return nil
+}
+
+// removeDevice removes the device from the store, and publishes its removal. If the device
+// was already removed by a device event from the server, its removal is not published again.
+func (a *adapter) removeDevice(device bluetooth.DeviceEventData) {
+	if a.s.store.RemoveDevice(device.DeviceAddress) {
+		bluetooth.DeviceEvents().PublishRemoved(device)
+	}
+}
+
+// SetProperty sets a raw property of the adapter.
+// Currently is valid only on Linux.
+func (a *adapter) SetProperty(_ string, _ any) error {
+	return errorkinds.ErrNotSupported
+}
+
+// GetProperty gets a raw property of the adapter.
+// Currently is valid only on Linux.
+func (a *adapter) GetProperty(_ string) (any, error) {
+	return nil, errorkinds.ErrNotSupported
+}
+
 // Properties returns all the properties of the adapter.
 func (a *adapter) Properties() (bluetooth.AdapterData, error) {
 	return a.check()
@@ -185,9 +262,22 @@ func (a *adapter) check() (bluetooth.AdapterData, error) {
 	return adapter, nil
 }
 
+// publishPowerState updates the power state of the adapter in the store, and publishes the update.
+func (a *adapter) publishPowerState(state bluetooth.AdapterPowerState) {
+	updated, err := a.s.store.SetAdapterPowerState(a.key, state)
+	if err != nil {
+		return
+	}
+
+	bluetooth.AdapterEvents().PublishUpdated(updated)
+}
+
 // appendProperties appends any additional properties to the provided adapter and returns
 // the new result.
-// It is currently a placeholder function only.
 func (a *adapter) appendProperties(adapter bluetooth.AdapterData) (bluetooth.AdapterData, error) {
+	if adapter.PowerState == "" {
+		adapter.PowerState = bluetooth.PowerStateFromPowered(adapter.Powered)
+	}
+
 	return adapter, nil
 }
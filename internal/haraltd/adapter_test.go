@@ -0,0 +1,118 @@
+//go:build !linux && haraltd
+
+package haraltd
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/commands"
+)
+
+func TestRemoveAllDevices(t *testing.T) {
+	mac := func(s string) bluetooth.MacAddress {
+		address, err := bluetooth.ParseMAC(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return address
+	}
+
+	adapterAddress := bluetooth.NewAdapterAddress(mac("00:00:00:00:00:0A"))
+	addresses := []string{"11:11:11:11:11:11", "22:22:22:22:22:22", "33:33:33:33:33:33"}
+
+	tests := []struct {
+		name        string
+		failed      []string
+		wantErr     bool
+		wantRemoved []string
+	}{
+		{name: "all removed", wantRemoved: addresses},
+		{name: "partial failure", failed: []string{"22:22:22:22:22:22"}, wantErr: true, wantRemoved: []string{"11:11:11:11:11:11", "33:33:33:33:33:33"}},
+		{name: "total failure", failed: addresses, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestSession(t, 0, func(command testCommand) (any, error) {
+				switch command.Name() {
+				case "adapter remove-all-devices":
+					if len(tt.failed) > 0 {
+						return nil, errors.New("device removal failed")
+					}
+
+				case "adapter get-paired-devices":
+					remaining := make([]commands.Device, 0, len(tt.failed))
+					for _, address := range tt.failed {
+						remaining = append(remaining, commands.Device{DeviceData: bluetooth.DeviceData{
+							DeviceEventData: bluetooth.DeviceEventData{DeviceAddress: bluetooth.NewDeviceAddress(mac(address), adapterAddress.Address)},
+						}})
+					}
+
+					return remaining, nil
+				}
+
+				return nil, nil
+			})
+
+			s.store.AddAdapter(bluetooth.AdapterData{AdapterEventData: bluetooth.AdapterEventData{AdapterAddress: adapterAddress}})
+			for _, address := range addresses {
+				s.store.AddDevice(bluetooth.DeviceData{DeviceEventData: bluetooth.DeviceEventData{
+					DeviceAddress: bluetooth.NewDeviceAddress(mac(address), adapterAddress.Address),
+				}})
+			}
+
+			sub, ok := bluetooth.DeviceEvents().Subscribe()
+			if !ok {
+				t.Fatal("cannot subscribe to device events")
+			}
+			defer sub.Unsubscribe()
+
+			// The events are buffered until all the devices are removed, since
+			// events which are not received immediately are otherwise dropped.
+			sub.Pause(bluetooth.PauseMode{BufferSize: len(addresses)})
+
+			err := s.Adapter(adapterAddress).RemoveAllDevices()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RemoveAllDevices() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			sub.Resume()
+
+			var removed []string
+			for len(removed) < len(tt.wantRemoved) {
+				select {
+				case device := <-sub.RemovedEvents:
+					removed = append(removed, device.Address.String())
+
+				case <-time.After(time.Second):
+					t.Fatalf("received removal events for %v, want %v", removed, tt.wantRemoved)
+				}
+			}
+
+			select {
+			case device := <-sub.RemovedEvents:
+				removed = append(removed, device.Address.String())
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			slices.Sort(removed)
+			if !slices.Equal(removed, tt.wantRemoved) {
+				t.Errorf("removal events = %v, want %v", removed, tt.wantRemoved)
+			}
+
+			remaining, err := s.store.AdapterDevices(adapterAddress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(remaining) != len(tt.failed) {
+				t.Errorf("store has %d devices, want %d", len(remaining), len(tt.failed))
+			}
+		})
+	}
+}
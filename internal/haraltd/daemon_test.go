@@ -0,0 +1,110 @@
+//go:build !linux && haraltd
+
+package haraltd
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
+	"github.com/bluetuith-org/bluetooth-classic/api/config"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/commands"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/serde"
+)
+
+// testCommand describes a command that was received by the test daemon.
+type testCommand []string
+
+// Name returns the name of the command, without its options.
+func (c testCommand) Name() string {
+	name, _, _ := strings.Cut(strings.Join(c, " "), " --")
+	return name
+}
+
+// Option returns the value of the provided option of the command.
+func (c testCommand) Option(option commands.Option) string {
+	if i := slices.Index(c, option.String()); i >= 0 && i+1 < len(c) {
+		return c[i+1]
+	}
+
+	return ""
+}
+
+// testDaemon emulates the 'haraltd' daemon over an in-memory connection. Each command
+// that is sent by the session is passed to the handler, which returns the result of the
+// command, or an error.
+type testDaemon struct {
+	conn    net.Conn
+	handler func(command testCommand) (any, error)
+
+	mu sync.Mutex
+}
+
+// newTestSession returns a started session with the provided features, which is connected
+// to a test daemon that handles commands using the provided handler.
+func newTestSession(t *testing.T, features ac.Features, handler func(command testCommand) (any, error)) (*HaraltdSession, *testDaemon) {
+	t.Helper()
+
+	s := &HaraltdSession{}
+
+	ctx := s.reset(false)
+	t.Cleanup(func() { s.Stop() })
+
+	conn, peer := net.Pipe()
+	daemon := &testDaemon{conn: peer, handler: handler}
+
+	s.conn = conn
+	s.maxEventSize = config.DefaultMaxEventSize
+	s.features = ac.NewFeatureSet(features, ac.Errors{})
+
+	go s.listen(ctx)
+	go daemon.serve()
+
+	return s, daemon
+}
+
+// serve handles the commands which are sent by the session until the connection is closed.
+func (d *testDaemon) serve() {
+	scanner := bufio.NewScanner(d.conn)
+
+	for scanner.Scan() {
+		var request struct {
+			Command   []string `json:"command"`
+			RequestID int64    `json:"request_id"`
+		}
+
+		if err := serde.UnmarshalJSON(scanner.Bytes(), &request); err != nil {
+			continue
+		}
+
+		response := map[string]any{"request_id": request.RequestID, "status": "ok"}
+
+		result, err := d.handler(testCommand(request.Command))
+		if err != nil {
+			response["status"] = "error"
+			response["error"] = commands.CommandError{Name: err.Error()}
+		} else {
+			response["data"] = map[string]any{"result": result}
+		}
+
+		d.send(response)
+	}
+}
+
+// send sends the provided response or event to the session.
+func (d *testDaemon) send(message any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := serde.MarshalJSON(message)
+	if err != nil {
+		return
+	}
+
+	_, _ = d.conn.Write(append(bytes.Clone(data), '\n'))
+}
@@ -4,6 +4,7 @@ package haraltd
 
 import (
 	"context"
+	"time"
 
 	"github.com/Southclaws/fault"
 	"github.com/Southclaws/fault/fctx"
@@ -23,8 +24,7 @@ type device struct {
 
 // Pair will attempt to pair a bluetooth device that is in pairing mode.
 func (d *device) Pair() error {
-	_, err := commands.Pair(d.key.Address).ExecuteWith(d.s.executor)
-	return err
+	return d.pair(context.Background())
 }
 
 // CancelPairing will cancel a pairing attempt.
@@ -36,8 +36,27 @@ func (d *device) CancelPairing() error {
 // Connect will attempt to connect an already paired bluetooth device
 // to an device.
 func (d *device) Connect() error {
-	_, err := commands.Connect(d.key.Address).ExecuteWith(d.s.executor)
-	return err
+	return d.connect(context.Background())
+}
+
+// PairAndConnect will attempt to pair a bluetooth device, wait for its services
+// to be resolved, and then connect the device to the adapter.
+func (d *device) PairAndConnect(ctx context.Context) error {
+	if _, err := d.check(); err != nil {
+		return err
+	}
+
+	ctx, cancel := d.s.operationContext(ctx)
+	defer cancel()
+
+	return d.s.store.PairAndConnect(ctx, d.key, d.pair, d.connect)
+}
+
+// PairWithCapability will attempt to pair a bluetooth device, using the provided
+// authentication capability for this pairing attempt only.
+// Currently is not supported.
+func (d *device) PairWithCapability(_ context.Context, _ bluetooth.AgentCapability) error {
+	return errorkinds.ErrNotSupported
 }
 
 // Disconnect will disconnect the bluetooth device from the device.
@@ -49,7 +68,10 @@ func (d *device) Disconnect() error {
 // ConnectProfile will attempt to connect an already paired bluetooth device
 // to an device, using a specific Bluetooth profile UUID .
 func (d *device) ConnectProfile(profileUUID uuid.UUID) error {
-	_, err := commands.ConnectProfile(d.key.Address, profileUUID).ExecuteWith(d.s.executor)
+	ctx, cancel := d.s.operationContext(context.Background())
+	defer cancel()
+
+	_, err := commands.ConnectProfile(d.key.Address, profileUUID).ExecuteWithContext(ctx, d.s.executor)
 
 	return err
 }
@@ -80,6 +102,27 @@ func (d *device) SetBlocked(_ bool) error {
 	return errorkinds.ErrNotSupported
 }
 
+// SetProperty sets a raw property of the device.
+// Currently is valid only on Linux.
+func (d *device) SetProperty(_ string, _ any) error {
+	return errorkinds.ErrNotSupported
+}
+
+// GetProperty gets a raw property of the device.
+// Currently is valid only on Linux.
+func (d *device) GetProperty(_ string) (any, error) {
+	return nil, errorkinds.ErrNotSupported
+}
+
+// ConnectionParameters returns the negotiated connection parameters of the device.
+func (d *device) ConnectionParameters() (bluetooth.ConnParams, error) {
+	if _, err := d.check(); err != nil {
+		return bluetooth.ConnParams{}, err
+	}
+
+	return commands.ConnectionParameters(d.key.Address).ExecuteWith(d.s.executor)
+}
+
 // Properties returns all the properties of the device.
 func (d *device) Properties() (bluetooth.DeviceData, error) {
 	return d.check()
@@ -116,6 +159,46 @@ func (d *device) check() (bluetooth.DeviceData, error) {
 	return device, nil
 }
 
+// pair attempts to pair with the device until the context (ctx) is done.
+// If the context does not have a deadline, the session's operation timeout is applied.
+func (d *device) pair(ctx context.Context) error {
+	ctx, cancel := d.s.operationContext(ctx)
+	defer cancel()
+
+	_, err := commands.Pair(d.key.Address).ExecuteWithContext(ctx, d.s.executor)
+	if err != nil && ctx.Err() != nil {
+		_ = d.CancelPairing()
+	}
+
+	return err
+}
+
+// connect attempts to connect to the device until the context (ctx) is done.
+// If the context does not have a deadline, the session's operation timeout is applied.
+func (d *device) connect(ctx context.Context) error {
+	ctx, cancel := d.s.operationContext(ctx)
+	defer cancel()
+
+	_, err := commands.Connect(d.key.Address).ExecuteWithContext(ctx, d.s.executor)
+
+	return err
+}
+
+// linkQuality samples the link quality of the device. Haraltd only exposes
+// the RSSI of the device, which may not be available for all connected devices.
+func (d *device) linkQuality() (bluetooth.LinkQuality, error) {
+	quality := bluetooth.LinkQuality{Time: time.Now()}
+
+	device, err := commands.DeviceProperties(d.key.Address).ExecuteWith(d.s.executor)
+	if err != nil {
+		return quality, err
+	}
+
+	quality.RSSI = device.RSSI
+
+	return quality, nil
+}
+
 // appendProperties appends any additional properties to the provided device and returns
 // the new result.
 func (d *device) appendProperties(device bluetooth.DeviceData, adapter bluetooth.AdapterData) (bluetooth.DeviceData, error) {
@@ -0,0 +1,59 @@
+//go:build !linux && haraltd
+
+package haraltd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+)
+
+func TestConnectionParameters(t *testing.T) {
+	mac, err := bluetooth.ParseMAC("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := bluetooth.NewDeviceAddress(mac, mac)
+	sample := bluetooth.ConnParams{Interval: 24, Latency: 4, SupervisionTimeout: 72}
+
+	tests := []struct {
+		name    string
+		stored  bool
+		linkErr error
+		want    bluetooth.ConnParams
+		wantErr bool
+	}{
+		{name: "LE link", stored: true, want: sample},
+		{name: "classic-only link", stored: true, linkErr: errors.New("connection parameters are not available"), wantErr: true},
+		{name: "unknown device", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestSession(t, 0, func(command testCommand) (any, error) {
+				if command.Name() != "device connection-parameters" {
+					return nil, nil
+				}
+				if tt.linkErr != nil {
+					return nil, tt.linkErr
+				}
+
+				return sample, nil
+			})
+
+			if tt.stored {
+				s.store.AddDevice(bluetooth.DeviceData{DeviceEventData: bluetooth.DeviceEventData{DeviceAddress: address}})
+			}
+
+			got, err := s.Device(address).ConnectionParameters()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConnectionParameters() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ConnectionParameters() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
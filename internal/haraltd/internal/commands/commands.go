@@ -3,6 +3,7 @@
 package commands
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -29,6 +30,11 @@ func GetPlatformInfo() *Command[platforminfo.PlatformInfo] {
 	return &Command[platforminfo.PlatformInfo]{cmd: "rpc platform-info"}
 }
 
+// GetStateSnapshot invokes the "rpc state-snapshot" command.
+func GetStateSnapshot() *Command[StateSnapshot] {
+	return &Command[StateSnapshot]{cmd: "rpc state-snapshot"}
+}
+
 // AuthenticationReply invokes the "rpc auth" command.
 func AuthenticationReply(id int, input string) *Command[NoResult] {
 	return (&Command[NoResult]{cmd: "rpc auth"}).WithOptions(func(am OptionMap) {
@@ -48,8 +54,13 @@ func AdapterProperties(Address bluetooth.MacAddress) *Command[bluetooth.AdapterD
 }
 
 // GetPairedDevices invokes the "adapter get-paired-devices" command.
-func GetPairedDevices(Address bluetooth.MacAddress) *Command[[]bluetooth.DeviceData] {
-	return (&Command[[]bluetooth.DeviceData]{cmd: "adapter get-paired-devices"}).WithOption(AddressOption, Address.String())
+func GetPairedDevices(Address bluetooth.MacAddress) *Command[[]Device] {
+	return (&Command[[]Device]{cmd: "adapter get-paired-devices"}).WithOption(AddressOption, Address.String())
+}
+
+// RemoveAllDevices invokes the "adapter remove-all-devices" command.
+func RemoveAllDevices(Address bluetooth.MacAddress) *Command[NoResult] {
+	return (&Command[NoResult]{cmd: "adapter remove-all-devices"}).WithOption(AddressOption, Address.String())
 }
 
 // SetPairableState invokes the "adapter set-pairable-state" command.
@@ -87,8 +98,13 @@ func StopDiscovery(Address bluetooth.MacAddress) *Command[NoResult] {
 }
 
 // DeviceProperties invokes the "device properties" command.
-func DeviceProperties(Address bluetooth.MacAddress) *Command[bluetooth.DeviceData] {
-	return (&Command[bluetooth.DeviceData]{cmd: "device properties"}).WithOption(AddressOption, Address.String())
+func DeviceProperties(Address bluetooth.MacAddress) *Command[Device] {
+	return (&Command[Device]{cmd: "device properties"}).WithOption(AddressOption, Address.String())
+}
+
+// ConnectionParameters invokes the "device connection-parameters" command.
+func ConnectionParameters(Address bluetooth.MacAddress) *Command[bluetooth.ConnParams] {
+	return (&Command[bluetooth.ConnParams]{cmd: "device connection-parameters"}).WithOption(AddressOption, Address.String())
 }
 
 // Pair invokes the "device pair" command.
@@ -165,55 +181,72 @@ func ResumeTransfer(Address bluetooth.MacAddress) *Command[NoResult] {
 	return (&Command[NoResult]{cmd: "device opp resume-transfer"}).WithOption(AddressOption, Address.String())
 }
 
+// SuspendTransferWithID invokes the "device opp suspend-transfer" command with a transfer ID.
+func SuspendTransferWithID(Address bluetooth.MacAddress, TransferID bluetooth.ObjectPushTransferID) *Command[NoResult] {
+	return SuspendTransfer(Address).WithOption(TransferIDOption, TransferID.String())
+}
+
+// ResumeTransferWithID invokes the "device opp resume-transfer" command with a transfer ID.
+func ResumeTransferWithID(Address bluetooth.MacAddress, TransferID bluetooth.ObjectPushTransferID) *Command[NoResult] {
+	return ResumeTransfer(Address).WithOption(TransferIDOption, TransferID.String())
+}
+
+// TransferProperties invokes the "device opp transfer-properties" command.
+func TransferProperties(Address bluetooth.MacAddress) *Command[bluetooth.ObjectPushData] {
+	return (&Command[bluetooth.ObjectPushData]{cmd: "device opp transfer-properties"}).WithOption(AddressOption, Address.String())
+}
+
+// TransferPropertiesWithID invokes the "device opp transfer-properties" command with a transfer ID.
+func TransferPropertiesWithID(Address bluetooth.MacAddress, TransferID bluetooth.ObjectPushTransferID) *Command[bluetooth.ObjectPushData] {
+	return TransferProperties(Address).WithOption(TransferIDOption, TransferID.String())
+}
+
 // ExecuteWith invokes a command on the server, and listens for and returns the result of the command invocation.
 func (c *Command[T]) ExecuteWith(fn ExecuteFunc, timeoutSeconds ...int) (T, error) {
-	var result T
-
 	timeout := CommandReplyTimeout
 	if timeoutSeconds != nil {
 		timeout = time.Duration(timeoutSeconds[0] * int(time.Second))
 	}
 
-	responseChan, commandErr := fn(c.Slice())
-	if commandErr != nil {
-		return result, commandErr
-	}
-
-	commandErr = errorkinds.ErrSessionStop
+	return c.execute(context.Background(), fn, timeout)
+}
 
-	select {
-	case response, ok := <-responseChan:
-		if !ok {
-			break
-		}
+// ExecuteWithContext invokes a command on the server, and listens for and returns the result of the
+// command invocation until the context (ctx) is done. If the context does not have a deadline,
+// this waits until the command completes.
+func (c *Command[T]) ExecuteWithContext(ctx context.Context, fn ExecuteFunc) (T, error) {
+	return c.execute(ctx, fn, 0)
+}
 
-		if response.Status == "error" {
-			return result, response.Error
-		}
+// execute invokes a command on the server, and listens for and returns the result of the command invocation.
+func (c *Command[T]) execute(ctx context.Context, fn ExecuteFunc, timeout time.Duration) (T, error) {
+	var result T
 
-		if response.Status == "ok" {
-			switch any(result).(type) {
-			case NoResult:
-				return result, nil
+	response, err := fn(ctx, c.Slice(), timeout)
+	if err != nil {
+		return result, err
+	}
 
-			default:
-			}
+	switch response.Status {
+	case "error":
+		return result, response.Error
 
-			reply := make(map[string]T, 1)
-			if err := serde.UnmarshalJSON(response.Data, &reply); err != nil {
-				return result, err
-			}
+	case "ok":
+		if _, ok := any(result).(NoResult); ok {
+			return result, nil
+		}
 
-			for _, mv := range reply {
-				result = mv
-			}
+		reply := make(map[string]T, 1)
+		if err := serde.UnmarshalJSON(response.Data, &reply); err != nil {
+			return result, err
+		}
 
-			commandErr = nil
+		for _, mv := range reply {
+			result = mv
 		}
 
-	case <-time.After(timeout):
-		commandErr = errorkinds.ErrMethodTimeout
+		return result, nil
 	}
 
-	return result, commandErr
+	return result, errorkinds.ErrSessionStop
 }
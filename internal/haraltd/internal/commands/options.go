@@ -29,6 +29,8 @@ const (
 	AuthenticationIDOption Option = "--authentication-id"
 	ResponseOption         Option = "--response"
 	AgentOption            Option = "--agent-type"
+	TransferIDOption       Option = "--transfer-id"
+	ChannelOption          Option = "--channel"
 )
 
 // String returns a string representation of the option.
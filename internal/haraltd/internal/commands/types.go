@@ -3,9 +3,11 @@
 package commands
 
 import (
+	"context"
 	"strings"
 	"time"
 
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	"github.com/ugorji/go/codec"
 )
 
@@ -13,8 +15,9 @@ import (
 const CommandReplyTimeout = 30 * time.Second
 
 type (
-	// ExecuteFunc describes an external function that is used to execute the command.
-	ExecuteFunc func(params []string) (chan CommandResponse, error)
+	// ExecuteFunc describes an external function that is used to execute the command,
+	// and wait for its response until the timeout elapses or the context (ctx) is done.
+	ExecuteFunc func(ctx context.Context, params []string, timeout time.Duration) (CommandResponse, error)
 
 	// OptionMap describes a map of options to a command.
 	OptionMap = map[Option]string
@@ -50,6 +53,35 @@ type CommandResponse struct {
 	Data        codec.Raw    `json:"data"`
 }
 
+// Device describes the properties of a device sent from the server.
+type Device struct {
+	bluetooth.DeviceData
+
+	// Connectable is nil if the server did not send whether the device is connectable.
+	Connectable *bool `json:"connectable,omitempty"`
+}
+
+// Data returns the device data. If the server did not send whether the device
+// is connectable, it is derived from the advertising data flags of the device.
+func (d Device) Data() bluetooth.DeviceData {
+	device := d.DeviceData
+	if d.Connectable != nil {
+		device.Connectable = *d.Connectable
+	} else {
+		device.Connectable = bluetooth.ConnectableFromAdvertisingFlags(device.AdvertisingFlags)
+	}
+
+	return device
+}
+
+// StateSnapshot describes a snapshot of the server's state, which holds
+// all the adapters, devices and active file transfers.
+type StateSnapshot struct {
+	Adapters  []bluetooth.AdapterData    `json:"adapters"`
+	Devices   []Device                   `json:"devices"`
+	Transfers []bluetooth.ObjectPushData `json:"transfers"`
+}
+
 // CommandError describes an error that occurred while invoking the command,
 // whcih is sent from the server.
 type CommandError struct {
@@ -0,0 +1,52 @@
+//go:build !linux && haraltd
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/serde"
+)
+
+func TestDeviceData(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		wantConnectable bool
+	}{
+		{"inquiry result", `{"address": "11:22:33:AA:BB:CC", "name": "Headset"}`, true},
+		{"dual-mode advertisement", `{"address": "11:22:33:AA:BB:CC", "advertising_flags": "Gg=="}`, true},
+		{"LE-only advertisement", `{"address": "11:22:33:AA:BB:CC", "advertising_flags": "Bg=="}`, false},
+		{"server reports connectable", `{"address": "11:22:33:AA:BB:CC", "advertising_flags": "Bg==", "connectable": true}`, true},
+		{"server reports not connectable", `{"address": "11:22:33:AA:BB:CC", "connectable": false}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var device Device
+			if err := serde.UnmarshalJSON([]byte(tt.data), &device); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %v", err)
+			}
+
+			data := device.Data()
+			if got := data.Address.String(); got != "11:22:33:AA:BB:CC" {
+				t.Errorf("Address = %s, want 11:22:33:AA:BB:CC", got)
+			}
+			if data.Connectable != tt.wantConnectable {
+				t.Errorf("Connectable = %v, want %v", data.Connectable, tt.wantConnectable)
+			}
+		})
+	}
+}
+
+func TestConnParamsDecode(t *testing.T) {
+	var params bluetooth.ConnParams
+	if err := serde.UnmarshalJSON([]byte(`{"interval": 24, "latency": 4, "supervision_timeout": 72}`), &params); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+
+	if want := (bluetooth.ConnParams{Interval: 24, Latency: 4, SupervisionTimeout: 72}); params != want {
+		t.Errorf("decoded %+v, want %+v", params, want)
+	}
+}
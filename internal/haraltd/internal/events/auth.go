@@ -5,11 +5,13 @@ package events
 import (
 	"errors"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/config"
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 )
 
@@ -37,9 +39,11 @@ const (
 )
 
 // AuthReply wraps a reply method and its associated reply.
+// If the authentication event was rejected, the reply is empty.
 type AuthReply struct {
 	ReplyMethod AuthReplyMethod
 	Reply       string
+	Rejected    bool
 }
 
 // AuthEventData describes an authentication event.
@@ -62,60 +66,177 @@ type AuthEventData struct {
 	ObjectPush bluetooth.ObjectPushData `json:"file_transfer,omitzero"`
 }
 
-// CallAuthorizer maps the authentication event to the registered 'SessionAuthorizer' handlers.
-func (a *AuthEventData) CallAuthorizer(authorizer bluetooth.SessionAuthorizer, cb func(authEvent AuthEventData, reply AuthReply, err error)) error {
+// passkeyDigits is the number of digits of a passkey.
+const passkeyDigits = 6
+
+// PasskeyDisplays tracks display-passkey authentication events. During pairing, the server can
+// send multiple display-passkey events with the same authentication ID, each with an increasing number
+// of entered digits, so that the progress can be shown. Each event is passed to the authorizer, but only
+// a single reply is sent for the authentication ID, once the display is complete or cancelled.
+type PasskeyDisplays struct {
+	displays map[int]*passkeyDisplay
+
+	mu sync.Mutex
+}
+
+// passkeyDisplay holds the state of a display-passkey authentication event.
+type passkeyDisplay struct {
+	entered uint16
+	replied bool
+}
+
+// NewPasskeyDisplays returns a new display-passkey event tracker.
+func NewPasskeyDisplays() *PasskeyDisplays {
+	return &PasskeyDisplays{displays: make(map[int]*passkeyDisplay)}
+}
+
+// Update records the number of entered digits of the display-passkey event, and returns
+// whether the event should be passed to the authorizer. Events which are stale, i.e an event
+// with the same or a greater number of entered digits was already received, are skipped.
+func (p *PasskeyDisplays) Update(a AuthEventData) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	display, ok := p.displays[a.AuthID]
+	if !ok {
+		p.displays[a.AuthID] = &passkeyDisplay{entered: a.Entered}
+
+		timeout := time.Duration(a.TimeoutMs) * time.Millisecond
+		if timeout <= 0 {
+			timeout = config.DefaultAuthTimeout
+		}
+
+		time.AfterFunc(timeout, func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+
+			delete(p.displays, a.AuthID)
+		})
+
+		return true
+	}
+
+	if a.Entered <= display.entered {
+		return false
+	}
+
+	display.entered = a.Entered
+
+	return true
+}
+
+// Reply returns whether a reply should be sent for the display-passkey event. A reply is sent only once
+// for an authentication ID, when all the digits of the passkey are entered, or if the display was cancelled
+// (i.e the authorizer rejected the event). Events which are stale are never replied to.
+func (p *PasskeyDisplays) Reply(a AuthEventData, cancelled bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	display, ok := p.displays[a.AuthID]
+	if !ok || display.replied || a.Entered < display.entered {
+		return false
+	}
+
+	if !cancelled && a.Entered < passkeyDigits {
+		return false
+	}
+
+	display.replied = true
+
+	return true
+}
+
+// authReplyGracePeriod is the additional time to wait for the server to acknowledge
+// a reply to an authentication event, after the authentication event has expired.
+const authReplyGracePeriod = 2 * time.Second
+
+// ReplyTimeout returns the duration to wait for the server to acknowledge a reply to the authentication event.
+func (a *AuthEventData) ReplyTimeout() time.Duration {
+	timeout := time.Duration(a.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = config.DefaultAuthTimeout
+	}
+
+	return timeout + authReplyGracePeriod
+}
+
+// CallAuthorizer maps the authentication event to the registered 'SessionAuthorizer' handlers,
+// and returns the reply to send to the server. If the authorizer rejects the authentication
+// event, the reply is empty.
+func (a *AuthEventData) CallAuthorizer(authorizer bluetooth.SessionAuthorizer) (AuthReply, error) {
 	if authorizer == nil {
-		return errors.New("authorizer cannot be nil")
+		return AuthReply{}, errors.New("authorizer cannot be nil")
 	}
 
 	var authfn func() (AuthReply, error)
 
+	timeout := bluetooth.NewAuthTimeout(time.Duration(a.TimeoutMs) * time.Millisecond)
+	defer timeout.Cancel()
+
+	record := bluetooth.AuthAuditRecord{
+		DeviceAddress: a.DeviceAddress,
+		Request:       bluetooth.AuthRequestType(a.EventID),
+	}
+
 	switch a.EventID {
 	case DisplayPinCode:
+		record.Pincode = a.Pincode
 		authfn = func() (AuthReply, error) {
-			return AuthReply{ReplyWithInput, a.Pincode},
-				authorizer.DisplayPinCode(bluetooth.NewAuthTimeout(time.Duration(a.TimeoutMs)*time.Millisecond), a.Pincode, a.DeviceAddress)
+			return AuthReply{ReplyMethod: ReplyWithInput, Reply: a.Pincode},
+				authorizer.DisplayPinCode(timeout, a.Pincode, a.DeviceAddress)
 		}
 
 	case DisplayPasskey:
+		record.Passkey, record.Entered = a.Passkey, a.Entered
 		authfn = func() (AuthReply, error) {
-			return AuthReply{ReplyWithInput, strconv.FormatUint(uint64(a.Passkey), 10)},
-				authorizer.DisplayPasskey(bluetooth.NewAuthTimeout(time.Duration(a.TimeoutMs)*time.Millisecond), a.Passkey, a.Entered, a.DeviceAddress)
+			return AuthReply{ReplyMethod: ReplyWithInput, Reply: strconv.FormatUint(uint64(a.Passkey), 10)},
+				authorizer.DisplayPasskey(timeout, a.Passkey, a.Entered, a.DeviceAddress)
 		}
 
 	case ConfirmPasskey:
+		record.Passkey = a.Passkey
 		authfn = func() (AuthReply, error) {
-			return AuthReply{ReplyYesNo, "yes"},
-				authorizer.ConfirmPasskey(bluetooth.NewAuthTimeout(time.Duration(a.TimeoutMs)*time.Millisecond), a.Passkey, a.DeviceAddress)
+			return AuthReply{ReplyMethod: ReplyYesNo, Reply: "yes"},
+				authorizer.ConfirmPasskey(timeout, a.Passkey, a.DeviceAddress)
 		}
 
 	case AuthorizePairing:
 		authfn = func() (AuthReply, error) {
-			return AuthReply{ReplyYesNo, "yes"},
-				authorizer.AuthorizePairing(bluetooth.NewAuthTimeout(time.Duration(a.TimeoutMs)*time.Millisecond), a.DeviceAddress)
+			return AuthReply{ReplyMethod: ReplyYesNo, Reply: "yes"},
+				authorizer.AuthorizePairing(timeout, a.DeviceAddress)
 		}
 
 	case AuthorizeService:
+		record.UUID = a.UUID
 		authfn = func() (AuthReply, error) {
-			return AuthReply{ReplyYesNo, "yes"},
-				authorizer.AuthorizeService(bluetooth.NewAuthTimeout(time.Duration(a.TimeoutMs)*time.Millisecond), a.UUID, a.DeviceAddress)
+			return AuthReply{ReplyMethod: ReplyYesNo, Reply: "yes"},
+				authorizer.AuthorizeService(timeout, a.UUID, a.DeviceAddress)
 		}
 
 	case AuthorizeTransfer:
+		record.ObjectPush = a.ObjectPush
+		if record.DeviceAddress.IsNil() {
+			record.DeviceAddress = a.ObjectPush.DeviceAddress
+		}
+
 		authfn = func() (AuthReply, error) {
-			return AuthReply{ReplyYesNo, "yes"},
-				authorizer.AuthorizeTransfer(bluetooth.NewAuthTimeout(time.Duration(a.TimeoutMs)*time.Millisecond), a.ObjectPush)
+			return AuthReply{ReplyMethod: ReplyYesNo, Reply: "yes"},
+				authorizer.AuthorizeTransfer(timeout, a.ObjectPush)
 		}
 	}
 
 	if authfn == nil {
-		return errorkinds.ErrMethodCall
+		return AuthReply{}, errorkinds.ErrMethodCall
 	}
 
 	reply, err := authfn()
-	cb(*a, reply, err)
+	bluetooth.AuditAuthorization(authorizer, timeout, record, err)
+
+	if err != nil {
+		reply.Reply, reply.Rejected = "", true
+	}
 
-	return nil
+	return reply, nil
 }
 
 // UnmarshalAuthEvent unmarshals a 'ServerEvent' to an authentication event.
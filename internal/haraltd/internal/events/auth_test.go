@@ -0,0 +1,176 @@
+//go:build !linux && haraltd
+
+package events
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/google/uuid"
+)
+
+func TestPasskeyDisplays(t *testing.T) {
+	type display struct {
+		entered   uint16
+		cancelled bool
+
+		wantAuthorize bool
+		wantReply     bool
+	}
+
+	tests := []struct {
+		name     string
+		displays []display
+	}{
+		{
+			name: "incremental entered digits",
+			displays: []display{
+				{entered: 0, wantAuthorize: true},
+				{entered: 1, wantAuthorize: true},
+				{entered: 3, wantAuthorize: true},
+				{entered: 6, wantAuthorize: true, wantReply: true},
+			},
+		},
+		{
+			name: "stale and repeated events",
+			displays: []display{
+				{entered: 2, wantAuthorize: true},
+				{entered: 2},
+				{entered: 1},
+				{entered: 6, wantAuthorize: true, wantReply: true},
+				{entered: 6},
+			},
+		},
+		{
+			name: "cancelled display",
+			displays: []display{
+				{entered: 0, wantAuthorize: true},
+				{entered: 2, cancelled: true, wantAuthorize: true, wantReply: true},
+				{entered: 6, wantAuthorize: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			displays := NewPasskeyDisplays()
+
+			for i, d := range tt.displays {
+				event := AuthEventData{AuthID: 1, EventID: DisplayPasskey, Entered: d.entered}
+
+				if got := displays.Update(event); got != d.wantAuthorize {
+					t.Fatalf("event %d: Update() = %v, want %v", i, got, d.wantAuthorize)
+				}
+				if !d.wantAuthorize {
+					continue
+				}
+
+				if got := displays.Reply(event, d.cancelled); got != d.wantReply {
+					t.Fatalf("event %d: Reply() = %v, want %v", i, got, d.wantReply)
+				}
+			}
+		})
+	}
+}
+
+// testAuthorizer rejects all requests if reject is set, waits for the requests to
+// time out if timeout is set, and otherwise accepts all requests.
+type testAuthorizer struct {
+	reject, timeout bool
+}
+
+func (a testAuthorizer) decide(timeout bluetooth.AuthTimeout) error {
+	switch {
+	case a.reject:
+		return errors.New("rejected")
+
+	case a.timeout:
+		<-timeout.Done()
+		return timeout.Err()
+	}
+
+	return nil
+}
+
+func (a testAuthorizer) AuthorizeTransfer(timeout bluetooth.AuthTimeout, _ bluetooth.ObjectPushData) error {
+	return a.decide(timeout)
+}
+
+func (a testAuthorizer) DisplayPinCode(timeout bluetooth.AuthTimeout, _ string, _ bluetooth.DeviceAddress) error {
+	return a.decide(timeout)
+}
+
+func (a testAuthorizer) DisplayPasskey(timeout bluetooth.AuthTimeout, _ uint32, _ uint16, _ bluetooth.DeviceAddress) error {
+	return a.decide(timeout)
+}
+
+func (a testAuthorizer) ConfirmPasskey(timeout bluetooth.AuthTimeout, _ uint32, _ bluetooth.DeviceAddress) error {
+	return a.decide(timeout)
+}
+
+func (a testAuthorizer) AuthorizePairing(timeout bluetooth.AuthTimeout, _ bluetooth.DeviceAddress) error {
+	return a.decide(timeout)
+}
+
+func (a testAuthorizer) AuthorizeService(timeout bluetooth.AuthTimeout, _ uuid.UUID, _ bluetooth.DeviceAddress) error {
+	return a.decide(timeout)
+}
+
+// testAuditor records every audited decision.
+type testAuditor struct {
+	records []bluetooth.AuthAuditRecord
+	mu      sync.Mutex
+}
+
+func (a *testAuditor) AuditAuthorization(record bluetooth.AuthAuditRecord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.records = append(a.records, record)
+}
+
+func TestCallAuthorizerAudit(t *testing.T) {
+	decisions := []struct {
+		name       string
+		authorizer testAuthorizer
+	}{
+		{"accept", testAuthorizer{}},
+		{"reject", testAuthorizer{reject: true}},
+		{"timeout", testAuthorizer{timeout: true}},
+	}
+
+	address := bluetooth.NewDeviceAddress(bluetooth.MacAddress{1, 2, 3, 4, 5, 6}, bluetooth.MacAddress{0, 0, 0, 0, 0, 1})
+
+	for _, eventID := range []AuthEventID{DisplayPinCode, DisplayPasskey, ConfirmPasskey, AuthorizePairing, AuthorizeService, AuthorizeTransfer} {
+		for _, decision := range decisions {
+			t.Run(string(eventID)+"/"+decision.name, func(t *testing.T) {
+				auditor := &testAuditor{}
+				event := AuthEventData{DeviceAddress: address, EventID: eventID, TimeoutMs: 10}
+
+				reply, err := event.CallAuthorizer(bluetooth.NewAuditedAuthorizer(decision.authorizer, auditor))
+				if err != nil {
+					t.Fatalf("CallAuthorizer() error = %v", err)
+				}
+
+				accepted := decision.name == "accept"
+				if reply.Rejected == accepted {
+					t.Errorf("reply rejected = %v, want %v", reply.Rejected, !accepted)
+				}
+
+				if len(auditor.records) != 1 {
+					t.Fatalf("auditor received %d records, want 1", len(auditor.records))
+				}
+
+				record := auditor.records[0]
+				if record.Request != bluetooth.AuthRequestType(eventID) || record.DeviceAddress != address {
+					t.Errorf("record is for %s (%s), want %s (%s)", record.Address, record.Request, address.Address, eventID)
+				}
+				if record.Authorized != accepted || record.TimedOut != (decision.name == "timeout") {
+					t.Errorf("record authorized = %v, timed out = %v, want %v, %v", record.Authorized, record.TimedOut, accepted, decision.name == "timeout")
+				}
+			})
+		}
+	}
+}
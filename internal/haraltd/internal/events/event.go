@@ -10,12 +10,13 @@ import (
 	"github.com/ugorji/go/codec"
 
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/commands"
 	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/serde"
 )
 
 // RawEvents represents a raw event constraint.
 type RawEvents interface {
-	bluetooth.Events | AuthEventData
+	bluetooth.Events | AuthEventData | commands.Device
 }
 
 // ServerEvent describes a raw event that was sent from the server.
@@ -0,0 +1,80 @@
+//go:build !linux && haraltd
+
+package events
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+)
+
+// rawFramesBufferSize is the number of raw events that are buffered for each subscriber.
+// If a subscriber's buffer is full, subsequent raw events are dropped for that subscriber.
+const rawFramesBufferSize = 64
+
+// RawFrames distributes the raw event frames that are received from the server to its
+// subscribers. Since frames are only copied and distributed if there are any subscribers,
+// [RawFrames.Active] should be checked before publishing a raw event.
+type RawFrames struct {
+	subscribers map[uint64]chan bluetooth.RawEvent
+	id          uint64
+	active      atomic.Int32
+
+	mu sync.Mutex
+}
+
+// Active returns whether there are any subscribers to the raw events.
+func (r *RawFrames) Active() bool {
+	return r.active.Load() > 0
+}
+
+// Subscribe subscribes to the raw events. The returned channel is closed once the
+// context (ctx) is cancelled, or the returned function is called.
+func (r *RawFrames) Subscribe(ctx context.Context) (<-chan bluetooth.RawEvent, func()) {
+	ch := make(chan bluetooth.RawEvent, rawFramesBufferSize)
+
+	r.mu.Lock()
+	if r.subscribers == nil {
+		r.subscribers = make(map[uint64]chan bluetooth.RawEvent)
+	}
+
+	r.id++
+	id := r.id
+	r.subscribers[id] = ch
+	r.active.Add(1)
+	r.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			delete(r.subscribers, id)
+			r.active.Add(-1)
+			close(ch)
+		})
+	}
+
+	stop := context.AfterFunc(ctx, unsubscribe)
+
+	return ch, func() {
+		stop()
+		unsubscribe()
+	}
+}
+
+// Publish sends the raw event to all subscribers.
+func (r *RawFrames) Publish(event bluetooth.RawEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ch := range r.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -3,9 +3,13 @@
 package serde
 
 import (
+	"reflect"
 	"sync"
 
 	"github.com/ugorji/go/codec"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+	"github.com/bluetuith-org/bluetooth-classic/internal/codecext"
 )
 
 // resolver holds an encoder and decoder.
@@ -27,6 +31,7 @@ func init() {
 	if !gendecoder.check {
 		gendecoder.jsonHandle = codec.JsonHandle{}
 		gendecoder.jsonHandle.TypeInfos = codec.NewTypeInfos([]string{"json"})
+		gendecoder.jsonHandle.SetInterfaceExt(reflect.TypeFor[optional.Optional[string]](), 1, codecext.OptionalStringExt{})
 		gendecoder.jsonEncoder = codec.NewEncoderBytes(&gendecoder.jsonData, &gendecoder.jsonHandle)
 		gendecoder.jsonDecoder = codec.NewDecoderBytes(gendecoder.jsonData, &gendecoder.jsonHandle)
 
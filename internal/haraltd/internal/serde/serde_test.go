@@ -0,0 +1,56 @@
+//go:build !linux && haraltd
+
+package serde
+
+import (
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+)
+
+func TestUnmarshalOptionalString(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantSet bool
+		want    string
+	}{
+		{name: "value", data: `{"name":"Headset"}`, wantSet: true, want: "Headset"},
+		{name: "empty value", data: `{"name":""}`, wantSet: true},
+		{name: "quoted value", data: `{"name":"\"Headset\""}`, wantSet: true, want: `"Headset"`},
+		{name: "absent", data: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var device bluetooth.DeviceEventData
+			if err := UnmarshalJSON([]byte(tt.data), &device); err != nil {
+				t.Fatalf("UnmarshalJSON() error = %v", err)
+			}
+
+			got, ok := device.Name.Get()
+			if ok != tt.wantSet || got != tt.want {
+				t.Errorf("Name = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantSet)
+			}
+		})
+	}
+}
+
+func TestMarshalOptionalString(t *testing.T) {
+	var device bluetooth.DeviceEventData
+	device.Name.Set("Headset")
+
+	data, err := MarshalJSON(device)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+
+	var decoded bluetooth.DeviceEventData
+	if err := UnmarshalJSON(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalJSON(%s) error = %v", data, err)
+	}
+
+	if got := decoded.Name.Value(); got != "Headset" {
+		t.Errorf("decoded name = %q, want %q", got, "Headset")
+	}
+}
@@ -3,6 +3,7 @@
 package haraltd
 
 import (
+	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 )
@@ -24,6 +25,15 @@ func (m *mediaPlayer) Properties() (bluetooth.MediaData, error) {
 	return bluetooth.MediaData{}, errorkinds.ErrNotSupported
 }
 
+// Features returns the media features supported by the device.
+// Media control is currently not supported, so the media player feature is always absent.
+func (m *mediaPlayer) Features() (*ac.FeatureSet, error) {
+	var ce ac.Errors
+	ce.Append(ac.NewError(ac.FeatureMediaPlayer, errorkinds.ErrNotSupported))
+
+	return ac.NewFeatureSet(ac.FeatureNone, ce), nil
+}
+
 // Play starts the media playback.
 func (m *mediaPlayer) Play() error {
 	return errorkinds.ErrNotSupported
@@ -4,6 +4,8 @@ package haraltd
 
 import (
 	"context"
+	"slices"
+	"strconv"
 
 	"github.com/Southclaws/fault"
 	"github.com/Southclaws/fault/fctx"
@@ -37,15 +39,26 @@ func (o *obex) ObjectPush() bluetooth.ObexObjectPush {
 // CreateSession creates a new Obex session with a device.
 // The context (ctx) can be provided in case this function call
 // needs to be cancelled, since this function call can take some time
-// to complete.
-func (o *obexObjectPush) CreateSession(ctx context.Context) error {
+// to complete. If the context does not have a deadline, the session's operation
+// timeout is applied.
+func (o *obexObjectPush) CreateSession(ctx context.Context, options ...bluetooth.ObexSessionOptions) error {
 	if err := o.check(); err != nil {
 		return err
 	}
 
-	_, err := commands.CreateSession(o.key.Address).ExecuteWith(o.s.executor)
-	if ctx.Err() == context.Canceled {
+	command := commands.CreateSession(o.key.Address)
+	if options != nil && options[0].Channel != 0 {
+		command = command.WithOption(commands.ChannelOption, strconv.Itoa(int(options[0].Channel)))
+	}
+
+	ctx, cancel := o.s.operationContext(ctx)
+	defer cancel()
+
+	_, err := command.ExecuteWithContext(ctx, o.s.executor)
+	if err != nil && ctx.Err() != nil {
 		o.RemoveSession()
+
+		return errorkinds.ContextError(ctx)
 	}
 
 	return err
@@ -68,6 +81,9 @@ func (o *obexObjectPush) SendFile(filepath string) (bluetooth.ObjectPushData, er
 	}
 
 	filetransfer, err := commands.SendFile(o.key.Address, filepath).ExecuteWith(o.s.executor)
+	if err == nil && filetransfer.TransferID != "" {
+		o.s.transfers.Store(filetransfer.TransferID, o.key)
+	}
 
 	return filetransfer, err
 }
@@ -102,6 +118,92 @@ func (o *obexObjectPush) ResumeTransfer() error {
 	return err
 }
 
+// Transfers returns the IDs of all the transfers that are queued or active
+// within the session.
+func (o *obexObjectPush) Transfers() ([]bluetooth.ObjectPushTransferID, error) {
+	if err := o.check(); err != nil {
+		return nil, err
+	}
+
+	var transfers []bluetooth.ObjectPushTransferID
+
+	o.s.transfers.Range(func(id bluetooth.ObjectPushTransferID, address bluetooth.DeviceAddress) bool {
+		if address.Address == o.key.Address {
+			transfers = append(transfers, id)
+		}
+
+		return true
+	})
+
+	slices.Sort(transfers)
+
+	return transfers, nil
+}
+
+// SuspendTransferWithID suspends the transfer with the provided transfer ID.
+func (o *obexObjectPush) SuspendTransferWithID(id bluetooth.ObjectPushTransferID) error {
+	if err := o.checkTransfer(id); err != nil {
+		return err
+	}
+
+	_, err := commands.SuspendTransferWithID(o.key.Address, id).ExecuteWith(o.s.executor)
+	return err
+}
+
+// ResumeTransferWithID resumes the transfer with the provided transfer ID.
+func (o *obexObjectPush) ResumeTransferWithID(id bluetooth.ObjectPushTransferID) error {
+	if err := o.checkTransfer(id); err != nil {
+		return err
+	}
+
+	_, err := commands.ResumeTransferWithID(o.key.Address, id).ExecuteWith(o.s.executor)
+	return err
+}
+
+// CanResume returns whether the transfer with the provided transfer ID can be resumed.
+// If the ID is empty, the first transfer that is associated with the device is checked.
+func (o *obexObjectPush) CanResume(id bluetooth.ObjectPushTransferID) (bool, error) {
+	command := commands.TransferProperties(o.key.Address)
+	if id != "" {
+		if err := o.checkTransfer(id); err != nil {
+			return false, err
+		}
+
+		command = commands.TransferPropertiesWithID(o.key.Address, id)
+	} else if err := o.check(); err != nil {
+		return false, err
+	}
+
+	transfer, err := command.ExecuteWith(o.s.executor)
+	if err != nil {
+		return false, err
+	}
+
+	return transfer.Status.Resumable(), nil
+}
+
+// checkTransfer checks whether the transfer with the provided transfer ID is associated with the device.
+func (o *obexObjectPush) checkTransfer(id bluetooth.ObjectPushTransferID) error {
+	if err := o.check(); err != nil {
+		return err
+	}
+
+	if address, ok := o.s.transfers.Load(id); !ok || address.Address != o.key.Address {
+		return fault.Wrap(
+			errorkinds.ErrPropertyDataParse,
+			fctx.With(
+				context.Background(),
+				"error_at", "obex-check-transfer",
+				"address", o.key.Address.String(),
+			),
+			ftag.With(ftag.NotFound),
+			fmsg.With("Cannot obtain file transfer data"),
+		)
+	}
+
+	return nil
+}
+
 func (o *obexObjectPush) check() error {
 	switch {
 	case !o.isEnabled || o.s == nil || o.s.sessionClosed.Load():
@@ -0,0 +1,198 @@
+//go:build !linux && haraltd
+
+package haraltd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/commands"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/events"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/serde"
+)
+
+func TestSuspendTransferWithID(t *testing.T) {
+	mac, err := bluetooth.ParseMAC("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := bluetooth.NewDeviceAddress(mac, mac)
+	suspended, running := bluetooth.ObjectPushTransferID("transfer0"), bluetooth.ObjectPushTransferID("transfer1")
+
+	var mu sync.Mutex
+	statuses := map[bluetooth.ObjectPushTransferID]bluetooth.ObjectPushStatus{
+		suspended: bluetooth.TransferActive,
+		running:   bluetooth.TransferActive,
+	}
+
+	status := func(id bluetooth.ObjectPushTransferID) bluetooth.ObjectPushStatus {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return statuses[id]
+	}
+
+	s, _ := newTestSession(t, ac.FeatureSendFile, func(command testCommand) (any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		id := bluetooth.ObjectPushTransferID(command.Option(commands.TransferIDOption))
+		if _, ok := statuses[id]; !ok {
+			return nil, errors.New("transfer not found")
+		}
+
+		switch command.Name() {
+		case "device opp suspend-transfer":
+			statuses[id] = bluetooth.TransferSuspended
+
+		case "device opp resume-transfer":
+			statuses[id] = bluetooth.TransferActive
+
+		case "device opp transfer-properties":
+			return bluetooth.ObjectPushData{ObjectPushEventData: bluetooth.ObjectPushEventData{
+				TransferID: id,
+				Status:     statuses[id],
+			}}, nil
+		}
+
+		return nil, nil
+	})
+
+	s.obexEnabled = true
+	s.transfers.Store(suspended, address)
+	s.transfers.Store(running, address)
+
+	objectPush := s.Obex(address).ObjectPush()
+
+	if err := objectPush.SuspendTransferWithID(suspended); err != nil {
+		t.Fatalf("SuspendTransferWithID() error = %v", err)
+	}
+
+	if got := status(suspended); got != bluetooth.TransferSuspended {
+		t.Errorf("suspended transfer status = %s, want %s", got, bluetooth.TransferSuspended)
+	}
+	if got := status(running); got != bluetooth.TransferActive {
+		t.Errorf("other transfer status = %s, want %s", got, bluetooth.TransferActive)
+	}
+
+	for id, want := range map[bluetooth.ObjectPushTransferID]bool{suspended: true, running: false} {
+		if got, err := objectPush.CanResume(id); err != nil || got != want {
+			t.Errorf("CanResume(%s) = (%v, %v), want (%v, nil)", id, got, err, want)
+		}
+	}
+
+	if err := objectPush.ResumeTransferWithID(suspended); err != nil {
+		t.Fatalf("ResumeTransferWithID() error = %v", err)
+	}
+
+	for _, id := range []bluetooth.ObjectPushTransferID{suspended, running} {
+		if got := status(id); got != bluetooth.TransferActive {
+			t.Errorf("transfer %s status = %s after resuming, want %s", id, got, bluetooth.TransferActive)
+		}
+	}
+
+	if err := objectPush.SuspendTransferWithID("transfer2"); err == nil {
+		t.Error("SuspendTransferWithID() with an unknown transfer succeeded, want an error")
+	}
+}
+
+func TestTransferDeviceAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		receiving bool
+	}{
+		{name: "sending"},
+		{name: "receiving", receiving: true},
+	}
+
+	adapter, err := bluetooth.ParseMAC("00:00:00:00:00:01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peer, err := bluetooth.ParseMAC("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := bluetooth.NewDeviceAddress(peer, adapter)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestSession(t, ac.FeatureSendFile|ac.FeatureReceiveFile, func(testCommand) (any, error) {
+				return nil, nil
+			})
+
+			transfer := bluetooth.ObjectPushData{
+				ObjectPushEventData: bluetooth.ObjectPushEventData{
+					TransferID: "transfer0",
+					Status:     bluetooth.TransferQueued,
+				},
+				Receiving: tt.receiving,
+			}
+
+			// A sent transfer is tracked once it is queued, and the address of a
+			// received transfer is only sent with the event which adds it.
+			if tt.receiving {
+				transfer.DeviceAddress = address
+			} else {
+				s.transfers.Store(transfer.TransferID, address)
+			}
+
+			sub, ok := bluetooth.ObjectPushEvents().Subscribe()
+			if !ok {
+				t.Fatal("cannot subscribe to object push events")
+			}
+			defer sub.Unsubscribe()
+
+			sub.Pause(bluetooth.PauseMode{BufferSize: 10})
+
+			updated := transfer.ObjectPushEventData
+			updated.DeviceAddress = bluetooth.DeviceAddress{}
+			updated.Status = bluetooth.TransferActive
+
+			s.handleListenerEvent(testObjectPushEvent(t, bluetooth.EventActionAdded, transfer))
+			s.handleListenerEvent(testObjectPushEvent(t, bluetooth.EventActionUpdated, updated))
+
+			time.Sleep(100 * time.Millisecond)
+			sub.Resume()
+
+			for _, action := range []bluetooth.EventAction{bluetooth.EventActionAdded, bluetooth.EventActionUpdated} {
+				var got bluetooth.DeviceAddress
+
+				select {
+				case added := <-sub.AddedEvents:
+					got = added.DeviceAddress
+
+				case updated := <-sub.UpdatedEvents:
+					got = updated.DeviceAddress
+
+				case <-time.After(time.Second):
+					t.Fatalf("transfer was not %s", action)
+				}
+
+				if got != address {
+					t.Errorf("%s transfer address = %s (adapter %s), want %s (adapter %s)",
+						action, got.Address, got.AssociatedAdapter, address.Address, address.AssociatedAdapter)
+				}
+			}
+		})
+	}
+}
+
+// testObjectPushEvent returns an object push event with the provided action.
+func testObjectPushEvent(t *testing.T, action bluetooth.EventAction, transfer any) events.ServerEvent {
+	t.Helper()
+
+	data, err := serde.MarshalJSON(map[string]any{"file_transfer_event": transfer})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return events.ServerEvent{EventID: bluetooth.EventObjectPush, EventAction: action, Event: data}
+}
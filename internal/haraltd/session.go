@@ -4,24 +4,30 @@ package haraltd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"net"
 	"os"
 	"path"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/Southclaws/fault"
 	"github.com/Southclaws/fault/fctx"
 	"github.com/Southclaws/fault/fmsg"
 	"github.com/Southclaws/fault/ftag"
+	"github.com/google/uuid"
 	"github.com/puzpuzpuz/xsync/v3"
 
 	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	"github.com/bluetuith-org/bluetooth-classic/api/config"
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/helpers/correlator"
 	sstore "github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
 	"github.com/bluetuith-org/bluetooth-classic/api/platforminfo"
 	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/commands"
@@ -43,13 +49,24 @@ type HaraltdSession struct {
 
 	cancel context.CancelFunc
 
-	id         *xsync.Counter
-	requestMap *xsync.MapOf[int64, chan commands.CommandResponse]
+	id        *xsync.Counter
+	requests  *correlator.Correlator[int64, commands.CommandResponse]
+	transfers *xsync.MapOf[bluetooth.ObjectPushTransferID, bluetooth.DeviceAddress]
 
-	store sstore.SessionStore
+	transferTimer   *bluetooth.ObjectPushTimer
+	passkeyDisplays *events.PasskeyDisplays
+	rawFrames       events.RawFrames
+
+	store     sstore.SessionStore
+	formatter atomic.Pointer[bluetooth.AddressFormatter]
 
 	obexEnabled bool
 
+	operationTimeout  time.Duration
+	maxEventSize      int
+	reconnectAttempts int
+	socketPath        string
+
 	sync.Mutex
 }
 
@@ -58,6 +75,10 @@ type HaraltdSession struct {
 const (
 	socketName     = "hd.sock"
 	implementation = "haraltd"
+
+	// reconnectInterval is the interval between reconnection attempts, which
+	// is increased with each successive attempt.
+	reconnectInterval = time.Second
 )
 
 // Start attempts to initialize a session with the system's Bluetooth daemon or service.
@@ -66,6 +87,8 @@ const (
 func (s *HaraltdSession) Start(authHandler bluetooth.SessionAuthorizer, cfg config.Configuration) (*ac.FeatureSet, platforminfo.PlatformInfo, error) {
 	var ce ac.Errors
 
+	cfg = cfg.WithDefaults()
+
 	platform := platforminfo.NewPlatformInfo("", implementation)
 
 	var initialized bool
@@ -97,7 +120,13 @@ func (s *HaraltdSession) Start(authHandler bluetooth.SessionAuthorizer, cfg conf
 
 	ctx := s.reset(false)
 
-	if err := s.startListener(ctx, cfg.SocketPath); err != nil {
+	s.maxEventSize = cfg.MaxEventSize
+
+	s.socketPath = cfg.SocketPath
+	s.reconnectAttempts = cfg.ReconnectAttempts
+	s.store.SetErrorHistoryCapacity(cfg.ErrorHistoryCapacity)
+
+	if err := s.startListener(ctx); err != nil {
 		return nil, platform,
 			fault.Wrap(
 				errors.New(err.Error()),
@@ -142,20 +171,38 @@ func (s *HaraltdSession) Start(authHandler bluetooth.SessionAuthorizer, cfg conf
 	initialized = true
 	platformInfo.Implementation = implementation
 
+	// haraltd does not report the version of the Bluetooth stack, or the address type
+	// and supported transports of the adapter, so only the adapter address is populated.
+	if adapters, err := s.store.Adapters(); err == nil && platformInfo.AdapterAddress == "" {
+		platformInfo.AdapterAddress = adapters[0].Address.String()
+	}
+
+	// The server may advertise features that are not yet known,
+	// so only enable the known features.
+	features = features.Known()
+
 	for _, absentFeatures := range features.AbsentFeatures() {
 		ce.Append(ac.NewError(absentFeatures, errorkinds.ErrNotSupported))
 	}
 
 	s.obexEnabled = cfg.EnableObexServices
+	s.operationTimeout = cfg.OperationTimeout
 
-	s.features = ac.NewFeatureSet(features, ce)
-	if s.features.Has(ac.FeatureSendFile, ac.FeatureReceiveFile) && cfg.EnableObexServices {
+	featureSet := ac.NewFeatureSet(features, ce)
+	featureSet.SetMissing(ac.OptionalFeaturePairingCapability)
+
+	// The listener is already running, and may resynchronize the session concurrently.
+	s.Lock()
+	s.features = featureSet
+	s.Unlock()
+
+	if featureSet.Has(ac.FeatureSendFile, ac.FeatureReceiveFile) && cfg.EnableObexServices {
 		if _, err := commands.RegisterAgent(commands.ObexAgent).ExecuteWith(s.executor); err != nil {
 			ce.Append(ac.NewError(ac.FeatureReceiveFile, err))
 		}
 	}
 
-	return s.features, platformInfo, nil
+	return featureSet, platformInfo, nil
 }
 
 // Stop attempts to stop a session with the system's Bluetooth daemon or service.
@@ -184,6 +231,59 @@ func (s *HaraltdSession) Device(address bluetooth.DeviceAddress) bluetooth.Devic
 	return &device{s, address}
 }
 
+// RefreshAdapter fetches the properties of the adapter from haraltd, updates the adapter
+// within the session store, and publishes an adapter update event.
+func (s *HaraltdSession) RefreshAdapter(address bluetooth.AdapterAddress) (bluetooth.AdapterData, error) {
+	a := &adapter{s, address}
+	if _, err := a.check(); err != nil {
+		return bluetooth.AdapterData{}, err
+	}
+
+	adapter, err := commands.AdapterProperties(address.Address).ExecuteWith(s.executor)
+	if err != nil {
+		return bluetooth.AdapterData{}, err
+	}
+
+	adapter, err = a.appendProperties(adapter)
+	if err != nil {
+		return bluetooth.AdapterData{}, err
+	}
+
+	s.store.AddAdapter(adapter)
+	bluetooth.AdapterEvents().PublishUpdated(adapter.AdapterEventData)
+
+	return adapter, nil
+}
+
+// RefreshDevice fetches the properties of the device from haraltd, updates the device
+// within the session store, and publishes a device update event.
+func (s *HaraltdSession) RefreshDevice(address bluetooth.DeviceAddress) (bluetooth.DeviceData, error) {
+	d := &device{s, address}
+	if _, err := d.check(); err != nil {
+		return bluetooth.DeviceData{}, err
+	}
+
+	adapter, err := s.store.Adapter(address.AdapterAddress())
+	if err != nil {
+		return bluetooth.DeviceData{}, err
+	}
+
+	properties, err := commands.DeviceProperties(address.Address).ExecuteWith(s.executor)
+	if err != nil {
+		return bluetooth.DeviceData{}, err
+	}
+
+	device, err := d.appendProperties(properties.Data(), adapter)
+	if err != nil {
+		return bluetooth.DeviceData{}, err
+	}
+
+	s.store.AddDevice(device)
+	bluetooth.DeviceEvents().PublishUpdated(device.DeviceEventData)
+
+	return device, nil
+}
+
 // Obex returns a function call interface to invoke obex related functions.
 func (s *HaraltdSession) Obex(address bluetooth.DeviceAddress) bluetooth.Obex {
 	return &obex{s, address, s.obexEnabled}
@@ -200,6 +300,93 @@ func (s *HaraltdSession) MediaPlayer(bluetooth.DeviceAddress) bluetooth.MediaPla
 	return &mediaPlayer{}
 }
 
+// WatchServicesResolved waits for the services of the device to be resolved, and sends
+// the device's service UUIDs once via the returned channel.
+func (s *HaraltdSession) WatchServicesResolved(ctx context.Context, address bluetooth.DeviceAddress) (<-chan []uuid.UUID, func()) {
+	return s.store.WatchServicesResolved(ctx, address)
+}
+
+// WatchConnectedDevices sends the list of connected devices via the returned channel,
+// each time a device connects or disconnects.
+func (s *HaraltdSession) WatchConnectedDevices(ctx context.Context) (<-chan []bluetooth.DeviceData, func()) {
+	return s.store.WatchConnectedDevices(ctx)
+}
+
+// WatchLinkQuality samples the link quality of the connected device at each interval,
+// and sends each sample via the returned channel.
+func (s *HaraltdSession) WatchLinkQuality(ctx context.Context, address bluetooth.DeviceAddress, interval time.Duration) (<-chan bluetooth.LinkQuality, func(), error) {
+	d := &device{s: s, key: address}
+	if _, err := d.check(); err != nil {
+		return nil, func() {}, err
+	}
+
+	return s.store.WatchLinkQuality(ctx, address, interval, d.linkQuality)
+}
+
+// SetLowPowerMode enables or disables the low-power mode, which suspends the link quality sampling.
+func (s *HaraltdSession) SetLowPowerMode(enable bool) {
+	s.store.SetLowPowerMode(enable)
+}
+
+// LowPowerMode returns whether the low-power mode is enabled.
+func (s *HaraltdSession) LowPowerMode() bool {
+	return s.store.LowPowerMode()
+}
+
+// WatchRawEvents sends the raw event frames that are received from haraltd via the returned channel,
+// alongside the regular typed events.
+func (s *HaraltdSession) WatchRawEvents(ctx context.Context) (<-chan bluetooth.RawEvent, func(), error) {
+	events, unsubscribe := s.rawFrames.Subscribe(ctx)
+
+	return events, unsubscribe, nil
+}
+
+// RecentErrors returns the 'n' most recently published errors of the session.
+func (s *HaraltdSession) RecentErrors(n int) []bluetooth.BluetoothError {
+	return s.store.RecentErrors(n)
+}
+
+// TransferStats returns the aggregate statistics of the file transfers with the device.
+func (s *HaraltdSession) TransferStats(address bluetooth.DeviceAddress) (bluetooth.TransferStats, error) {
+	return s.store.TransferStats(address)
+}
+
+// ResetTransferStats resets the file transfer statistics of the device.
+func (s *HaraltdSession) ResetTransferStats(address bluetooth.DeviceAddress) error {
+	return s.store.ResetTransferStats(address)
+}
+
+// SupportsObexTarget returns whether the device advertises the service of the Obex target.
+func (s *HaraltdSession) SupportsObexTarget(address bluetooth.MacAddress, target bluetooth.ObexTarget) (bool, error) {
+	return s.store.SupportsObexTarget(address, target)
+}
+
+// AddressFormatter returns the formatter that is used to display Bluetooth addresses.
+func (s *HaraltdSession) AddressFormatter() bluetooth.AddressFormatter {
+	if formatter := s.formatter.Load(); formatter != nil {
+		return *formatter
+	}
+
+	return bluetooth.AddressFormatter{}
+}
+
+// SetAddressFormatter sets the formatter that is used to display Bluetooth addresses.
+func (s *HaraltdSession) SetAddressFormatter(formatter bluetooth.AddressFormatter) {
+	s.formatter.Store(&formatter)
+}
+
+// ExperimentalFeatures returns the identifiers of the experimental features that are enabled
+// within the Bluetooth daemon. This is currently not exposed by haraltd, so an empty list is returned.
+func (s *HaraltdSession) ExperimentalFeatures() ([]string, error) {
+	return []string{}, nil
+}
+
+// operationContext returns a context derived from the provided context (ctx), for long-running
+// commands. If the context does not have a deadline, the session's operation timeout is applied.
+func (s *HaraltdSession) operationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return config.WithOperationTimeout(ctx, s.operationTimeout)
+}
+
 // emptyAdapter returns an aapter-related function call interface for internal use.
 // This is used primarily to initialize emptyAdapter objects.
 func (s *HaraltdSession) emptyAdapter() *adapter {
@@ -232,7 +419,7 @@ func (s *HaraltdSession) refreshStore() error {
 			return err
 		}
 		for _, device := range devices {
-			newDevice, err := s.emptyDevice().appendProperties(device, adapter)
+			newDevice, err := s.emptyDevice().appendProperties(device.Data(), adapter)
 			if err != nil {
 				return err
 			}
@@ -245,30 +432,32 @@ func (s *HaraltdSession) refreshStore() error {
 }
 
 // startListener starts the socket and the listener.
-func (s *HaraltdSession) startListener(ctx context.Context, socketpath string) error {
-	socket, err := net.Dial("unix", socketpath)
+func (s *HaraltdSession) startListener(ctx context.Context) error {
+	socket, err := net.Dial("unix", s.socketPath)
 	if err != nil {
 		return err
 	}
 
+	s.Lock()
 	s.conn = socket
+	s.Unlock()
+
 	go s.listen(ctx)
 
 	return nil
 }
 
+// connection returns the current connection to the server. The connection is
+// replaced once the session reconnects, so it must always be accessed via the lock.
+func (s *HaraltdSession) connection() net.Conn {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.conn
+}
+
 // listen listens to the socket for any incoming messages and events.
 func (s *HaraltdSession) listen(ctx context.Context) {
-	sendData := func(c chan commands.CommandResponse, m commands.CommandResponse) {
-		select {
-		case <-ctx.Done():
-			close(c)
-		case c <- m:
-			close(c)
-		default:
-		}
-	}
-
 	for {
 		select {
 		case <-ctx.Done():
@@ -281,8 +470,11 @@ func (s *HaraltdSession) listen(ctx context.Context) {
 			return
 		}
 
-		scanner := bufio.NewScanner(s.conn)
-		scanner.Split(bufio.ScanLines)
+		// The buffer holds an additional byte for the line delimiter, so that
+		// events which are exactly at the maximum event size are not discarded.
+		scanner := bufio.NewScanner(s.connection())
+		scanner.Buffer(make([]byte, 0, min(bufio.MaxScanTokenSize, s.maxEventSize+1)), s.maxEventSize+1)
+		scanner.Split(s.scanEvents())
 
 		for scanner.Scan() {
 			var response struct {
@@ -295,22 +487,231 @@ func (s *HaraltdSession) listen(ctx context.Context) {
 				return
 			}
 
-			if err := serde.UnmarshalJSON(scanner.Bytes(), &response); err != nil {
+			err := serde.UnmarshalJSON(scanner.Bytes(), &response)
+			if err != nil {
 				s.handleListenerError(err, false)
 			}
 
+			// Frames are only copied if there are any subscribers to the raw events.
+			if s.rawFrames.Active() && (response.EventID > 0 || err != nil) {
+				s.rawFrames.Publish(bluetooth.RawEvent{
+					ID:     response.EventID,
+					Action: response.EventAction,
+					Frame:  bytes.Clone(scanner.Bytes()),
+					Err:    err,
+				})
+			}
+
 			if response.EventID > 0 {
 				go s.handleListenerEvent(response.ServerEvent)
 				continue
 			}
 
-			replyChan, ok := s.requestMap.LoadAndDelete(int64(response.RequestID))
-			if ok {
-				sendData(replyChan, response.CommandResponse)
+			s.requests.Resolve(int64(response.RequestID), response.CommandResponse)
+		}
+
+		if s.sessionClosed.Load() {
+			return
+		}
+
+		if s.reconnectAttempts <= 0 {
+			s.handleListenerError(scanner.Err(), true)
+			return
+		}
+
+		if err := s.reconnect(ctx, scanner.Err()); err != nil {
+			if !s.sessionClosed.Load() {
+				s.handleListenerError(err, true)
 			}
+
+			return
 		}
+	}
+}
+
+// reconnect attempts to re-establish the connection with the server after the connection
+// was lost (with the provided cause), and then resynchronizes the session with the server's state.
+// Pending requests which were sent over the lost connection are cancelled, since their responses
+// will never be received.
+func (s *HaraltdSession) reconnect(ctx context.Context, cause error) error {
+	s.requests.CancelAll()
 
-		s.handleListenerError(scanner.Err(), true)
+	if cause != nil {
+		s.handleListenerError(cause, false)
+	}
+
+	err := cause
+	for attempt := 1; attempt <= s.reconnectAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-time.After(time.Duration(attempt) * reconnectInterval):
+		}
+
+		var socket net.Conn
+
+		socket, err = net.Dial("unix", s.socketPath)
+		if err != nil {
+			continue
+		}
+
+		s.Lock()
+		if s.sessionClosed.Load() {
+			s.Unlock()
+			socket.Close()
+
+			return errorkinds.ErrSessionNotExist
+		}
+
+		s.conn.Close()
+		s.conn = socket
+		s.Unlock()
+
+		go s.resynchronize()
+
+		return nil
+	}
+
+	if err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return fault.Wrap(
+		err,
+		fctx.With(context.Background(), "error_at", "listener-reconnect", "attempts", strconv.Itoa(s.reconnectAttempts)),
+		ftag.With(ftag.Internal),
+		fmsg.With("Cannot reconnect to haraltd"),
+	)
+}
+
+// resynchronize registers the agents with the server again, and reconciles the session store
+// with a snapshot of the server's state, so that any changes which occurred while the connection
+// was lost are published.
+func (s *HaraltdSession) resynchronize() {
+	// The features are read under the lock, since they are reset when the session is stopped.
+	s.Lock()
+	features := s.features
+	s.Unlock()
+
+	if s.obexEnabled && features != nil && features.Has(ac.FeatureSendFile, ac.FeatureReceiveFile) {
+		if _, err := commands.RegisterAgent(commands.ObexAgent).ExecuteWith(s.executor); err != nil {
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
+		}
+	}
+
+	snapshot, err := commands.GetStateSnapshot().ExecuteWith(s.executor)
+	if err != nil {
+		s.store.ErrorEvents().PublishAdded(wrapError(err))
+		return
+	}
+
+	s.reconcile(snapshot)
+}
+
+// reconcile applies the snapshot of the server's state to the session store, and publishes
+// events for all the adapters, devices and file transfers which were added, updated or removed.
+func (s *HaraltdSession) reconcile(snapshot commands.StateSnapshot) {
+	adapters := make([]bluetooth.AdapterData, 0, len(snapshot.Adapters))
+	adapterMap := make(map[bluetooth.MacAddress]bluetooth.AdapterData, len(snapshot.Adapters))
+	for _, adapter := range snapshot.Adapters {
+		adapter, err := s.emptyAdapter().appendProperties(adapter)
+		if err != nil {
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
+			return
+		}
+
+		adapters = append(adapters, adapter)
+		adapterMap[adapter.Address] = adapter
+	}
+
+	devices := make([]bluetooth.DeviceData, 0, len(snapshot.Devices))
+	for _, device := range snapshot.Devices {
+		adapter, ok := adapterMap[device.AssociatedAdapter]
+		if !ok {
+			continue
+		}
+
+		device, err := s.emptyDevice().appendProperties(device.Data(), adapter)
+		if err != nil {
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
+			return
+		}
+
+		devices = append(devices, device)
+	}
+
+	s.store.Reconcile(adapters, devices)
+
+	active := make(map[bluetooth.ObjectPushTransferID]struct{}, len(snapshot.Transfers))
+	for _, filetransfer := range snapshot.Transfers {
+		active[filetransfer.TransferID] = struct{}{}
+
+		s.transferTimer.Track(&filetransfer.ObjectPushEventData)
+		if _, ok := s.transfers.LoadOrStore(filetransfer.TransferID, filetransfer.DeviceAddress); !ok {
+			s.store.AddTransfer(filetransfer)
+			bluetooth.ObjectPushEvents().PublishAdded(filetransfer)
+
+			continue
+		}
+
+		s.store.UpdateTransfer(filetransfer.ObjectPushEventData)
+		bluetooth.ObjectPushEvents().PublishUpdated(filetransfer.ObjectPushEventData)
+	}
+
+	// Transfers which ended while the connection was lost are removed. Since their final
+	// status is not known, they are counted as failures in the transfer statistics.
+	s.transfers.Range(func(id bluetooth.ObjectPushTransferID, address bluetooth.DeviceAddress) bool {
+		if _, ok := active[id]; ok {
+			return true
+		}
+
+		filetransfer := bluetooth.ObjectPushEventData{DeviceAddress: address, TransferID: id}
+
+		s.transfers.Delete(id)
+		s.transferTimer.Remove(&filetransfer)
+		s.store.RemoveTransfer(filetransfer)
+		bluetooth.ObjectPushEvents().PublishRemoved(filetransfer)
+
+		return true
+	})
+}
+
+// scanEvents returns a split function that splits the incoming data into lines (i.e events).
+// Any event which exceeds the maximum event size is discarded until the next line, and
+// an error is published, so that the listener can continue to process subsequent events.
+func (s *HaraltdSession) scanEvents() bufio.SplitFunc {
+	var discarding bool
+
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if discarding {
+			if i := bytes.IndexByte(data, '\n'); i >= 0 {
+				discarding = false
+
+				return i + 1, nil, nil
+			}
+
+			return len(data), nil, nil
+		}
+
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if advance == 0 && token == nil && len(data) > s.maxEventSize {
+			discarding = true
+
+			s.handleListenerError(
+				fault.Wrap(
+					errorkinds.ErrEventTooLarge,
+					fctx.With(context.Background(), "error_at", "listener-scan", "max_event_size", strconv.Itoa(s.maxEventSize)),
+					ftag.With(ftag.Internal),
+					fmsg.With("An event which exceeds the maximum event size was discarded"),
+				),
+				false,
+			)
+
+			return len(data), nil, nil
+		}
+
+		return advance, token, err
 	}
 }
 
@@ -327,35 +728,41 @@ func (s *HaraltdSession) handleListenerEvent(ev events.ServerEvent) {
 			genError = errorEvent
 		}
 
-		bluetooth.ErrorEvents().PublishAdded(wrapError(genError))
+		s.store.ErrorEvents().PublishAdded(wrapError(genError))
 
 	case bluetooth.EventAuthentication:
 		authEvent, err := events.UnmarshalAuthEvent(ev)
 		if err != nil {
-			bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
 			return
 		}
 
-		err = authEvent.CallAuthorizer(s.authorizer, func(authEvent events.AuthEventData, reply events.AuthReply, err error) {
-			var response string
-
-			if err == nil {
-				response = reply.Reply
-			}
+		isPasskeyDisplay := authEvent.EventID == events.DisplayPasskey
+		if isPasskeyDisplay && !s.passkeyDisplays.Update(authEvent) {
+			return
+		}
 
-			_, err = commands.AuthenticationReply(authEvent.AuthID, response).ExecuteWith(s.executor, (authEvent.TimeoutMs/1000)+2)
-			if err != nil {
-				bluetooth.ErrorEvents().PublishAdded(wrapError(err))
-			}
-		})
+		reply, err := authEvent.CallAuthorizer(s.authorizer)
 		if err != nil {
-			bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
+			return
+		}
+
+		if isPasskeyDisplay && !s.passkeyDisplays.Reply(authEvent, reply.Rejected) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), authEvent.ReplyTimeout())
+		defer cancel()
+
+		if _, err := commands.AuthenticationReply(authEvent.AuthID, reply.Reply).ExecuteWithContext(ctx, s.executor); err != nil {
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
 		}
 
 	case bluetooth.EventAdapter:
 		adapter, err := events.Unmarshal[bluetooth.AdapterData](ev)
 		if err != nil {
-			bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
 			return
 		}
 
@@ -365,18 +772,38 @@ func (s *HaraltdSession) handleListenerEvent(ev events.ServerEvent) {
 
 			adapter, err := commands.AdapterProperties(adapter.Address).ExecuteWith(s.executor)
 			if err != nil {
-				bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+				s.store.ErrorEvents().PublishAdded(wrapError(err))
 				return
 			}
 
+			if adapter.PowerState == "" {
+				adapter.PowerState = bluetooth.PowerStateFromPowered(adapter.Powered)
+			}
+
 			s.store.AddAdapter(adapter)
 
 		case bluetooth.EventActionUpdated:
 			updated, err := s.store.UpdateAdapter(adapter.AdapterAddress, func(dd *bluetooth.AdapterData) error {
-				return events.UnmarshalRawEvent(ev, &dd.AdapterEventData)
+				prevPowered, prevState := dd.Powered, dd.PowerState
+
+				dd.PowerState = ""
+				if err := events.UnmarshalRawEvent(ev, &dd.AdapterEventData); err != nil {
+					return err
+				}
+
+				// The power state may not be sent by the shim, so infer it from
+				// the 'Powered' property if it has changed.
+				if dd.PowerState == "" {
+					dd.PowerState = prevState
+					if dd.Powered != prevPowered {
+						dd.PowerState = bluetooth.PowerStateFromPowered(dd.Powered)
+					}
+				}
+
+				return nil
 			})
 			if err != nil {
-				bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+				s.store.ErrorEvents().PublishAdded(wrapError(err))
 				return
 			}
 
@@ -388,12 +815,14 @@ func (s *HaraltdSession) handleListenerEvent(ev events.ServerEvent) {
 		}
 
 	case bluetooth.EventDevice:
-		device, err := events.Unmarshal[bluetooth.DeviceData](ev)
-		if err != nil {
-			bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+		var properties commands.Device
+		if err := events.UnmarshalRawEvent(ev, &properties); err != nil {
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
 			return
 		}
 
+		device := properties.Data()
+
 		switch ev.EventAction {
 		case bluetooth.EventActionAdded:
 			device.Type = bluetooth.DeviceTypeFromClass(device.Class)
@@ -406,7 +835,7 @@ func (s *HaraltdSession) handleListenerEvent(ev events.ServerEvent) {
 				return events.UnmarshalRawEvent(ev, &dd.DeviceEventData)
 			})
 			if err != nil {
-				bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+				s.store.ErrorEvents().PublishAdded(wrapError(err))
 				return
 			}
 
@@ -420,25 +849,41 @@ func (s *HaraltdSession) handleListenerEvent(ev events.ServerEvent) {
 	case bluetooth.EventObjectPush:
 		filetransfer, err := events.Unmarshal[bluetooth.ObjectPushData](ev)
 		if err != nil {
-			bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
 			return
 		}
 
+		// The address of a transfer is always the peer device, so if it
+		// is not sent with the event, use the address tracked for the transfer.
+		if filetransfer.DeviceAddress.IsNil() {
+			if address, ok := s.transfers.Load(filetransfer.TransferID); ok {
+				filetransfer.DeviceAddress = address
+			}
+		}
+
 		switch ev.EventAction {
 		case bluetooth.EventActionAdded:
+			s.transfers.Store(filetransfer.TransferID, filetransfer.DeviceAddress)
+			s.transferTimer.Track(&filetransfer.ObjectPushEventData)
+			s.store.AddTransfer(filetransfer)
 			bluetooth.ObjectPushEvents().PublishAdded(filetransfer)
 
 		case bluetooth.EventActionUpdated:
+			s.transferTimer.Track(&filetransfer.ObjectPushEventData)
+			s.store.UpdateTransfer(filetransfer.ObjectPushEventData)
 			bluetooth.ObjectPushEvents().PublishUpdated(filetransfer.ObjectPushEventData)
 
 		case bluetooth.EventActionRemoved:
+			s.transfers.Delete(filetransfer.TransferID)
+			s.transferTimer.Remove(&filetransfer.ObjectPushEventData)
+			s.store.RemoveTransfer(filetransfer.ObjectPushEventData)
 			bluetooth.ObjectPushEvents().PublishRemoved(filetransfer.ObjectPushEventData)
 		}
 
 	case bluetooth.EventMediaPlayer:
 		mediaplayer, err := events.Unmarshal[bluetooth.MediaData](ev)
 		if err != nil {
-			bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+			s.store.ErrorEvents().PublishAdded(wrapError(err))
 			return
 		}
 
@@ -460,7 +905,7 @@ func (s *HaraltdSession) handleListenerEvent(ev events.ServerEvent) {
 // and the application must exit.
 func (s *HaraltdSession) handleListenerError(err error, stop bool) {
 	if err != nil {
-		bluetooth.ErrorEvents().PublishAdded(wrapError(err))
+		s.store.ErrorEvents().PublishAdded(wrapError(err))
 	}
 
 	if stop {
@@ -469,40 +914,51 @@ func (s *HaraltdSession) handleListenerError(err error, stop bool) {
 }
 
 // executor forms a request using the provided parameters, generates a unique request ID,
-// and sends the request to the server. The request is tracked, and any responses to the
-// request will be handled by the listener.
+// and sends the request to the server. The request is tracked, and the response to the
+// request is correlated by the listener, which is then returned to the caller.
 //
 // This function is mainly used by the 'commands' package.
-func (s *HaraltdSession) executor(params []string) (chan commands.CommandResponse, error) {
+func (s *HaraltdSession) executor(ctx context.Context, params []string, timeout time.Duration) (commands.CommandResponse, error) {
 	if s.sessionClosed.Load() {
-		return nil, errorkinds.ErrSessionNotExist
+		return commands.CommandResponse{}, errorkinds.ErrSessionNotExist
 	}
 
 	s.Lock()
-	defer s.Unlock()
-
 	s.id.Inc()
-	replyChan := make(chan commands.CommandResponse, 1)
-	s.requestMap.Store(s.id.Value(), replyChan)
+	id := s.id.Value()
+	requests := s.requests
+	s.Unlock()
 
-	command := map[string]any{
-		"command":    params,
-		"request_id": s.id.Value(),
-	}
+	response, err := requests.Request(ctx, id, timeout, func() error {
+		s.Lock()
+		defer s.Unlock()
 
-	commandBytes, err := serde.MarshalJSON(command)
-	if err != nil {
-		return nil, err
-	}
+		command := map[string]any{
+			"command":    params,
+			"request_id": id,
+		}
 
-	if _, err = s.conn.Write(commandBytes); err != nil {
-		return nil, err
-	}
-	if _, err = s.conn.Write([]byte("\n")); err != nil {
-		return nil, err
+		commandBytes, err := serde.MarshalJSON(command)
+		if err != nil {
+			return err
+		}
+
+		if _, err = s.conn.Write(commandBytes); err != nil {
+			return err
+		}
+		_, err = s.conn.Write([]byte("\n"))
+
+		return err
+	})
+	switch {
+	case err != nil && ctx.Err() != nil:
+		err = errorkinds.ContextError(ctx)
+
+	case errors.Is(err, errorkinds.ErrMethodCanceled):
+		err = errorkinds.NewCancelError(errorkinds.CancelReasonSessionStopped, errorkinds.ErrSessionStop)
 	}
 
-	return replyChan, nil
+	return response, err
 }
 
 // reset resets the state of the session. If 'isClosed' is true (i.e the session is stopped),
@@ -522,7 +978,10 @@ func (s *HaraltdSession) reset(isClosed bool) context.Context {
 	}
 
 	s.id = xsync.NewCounter()
-	s.requestMap = xsync.NewMapOf[int64, chan commands.CommandResponse]()
+	s.requests = correlator.NewCorrelator[int64, commands.CommandResponse]()
+	s.transfers = xsync.NewMapOf[bluetooth.ObjectPushTransferID, bluetooth.DeviceAddress]()
+	s.transferTimer = bluetooth.NewObjectPushTimer()
+	s.passkeyDisplays = events.NewPasskeyDisplays()
 
 	s.listenerEvents = make(chan []byte, 1)
 
@@ -540,6 +999,10 @@ func (s *HaraltdSession) cleanup() {
 		s.cancel()
 	}
 
+	if s.requests != nil {
+		s.requests.CancelAll()
+	}
+
 	if s.conn != nil {
 		s.conn.Close()
 	}
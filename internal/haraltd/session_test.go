@@ -0,0 +1,422 @@
+//go:build !linux && haraltd
+
+package haraltd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/config"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/commands"
+	"github.com/bluetuith-org/bluetooth-classic/internal/haraltd/internal/serde"
+)
+
+func TestReconnect(t *testing.T) {
+	mac := func(s string) bluetooth.MacAddress {
+		address, err := bluetooth.ParseMAC(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return address
+	}
+
+	adapterAddress := bluetooth.NewAdapterAddress(mac("00:00:00:00:00:0A"))
+	device := func(address string, connected bool) bluetooth.DeviceData {
+		return bluetooth.DeviceData{
+			DeviceEventData: bluetooth.DeviceEventData{
+				DeviceAddress: bluetooth.NewDeviceAddress(mac(address), adapterAddress.Address),
+				Connected:     optional.New(connected),
+			},
+		}
+	}
+
+	// The session knows about the first two devices before the connection is lost. While
+	// disconnected, the first device is removed, the second device is connected and the
+	// third device is discovered, so the state snapshot of the daemon differs from the store.
+	known := []bluetooth.DeviceData{device("11:11:11:11:11:11", false), device("22:22:22:22:22:22", false)}
+	snapshot := commands.StateSnapshot{
+		Adapters: []bluetooth.AdapterData{{AdapterEventData: bluetooth.AdapterEventData{AdapterAddress: adapterAddress}}},
+		Devices: []commands.Device{
+			{DeviceData: device("22:22:22:22:22:22", true)},
+			{DeviceData: device("33:33:33:33:33:33", false)},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		listen      bool
+		attempts    int
+		closed      bool
+		cancelled   bool
+		fail        bool
+		wantErr     error
+		resync      bool
+		wantChanges []string
+	}{
+		{name: "reconnects", listen: true, attempts: 1},
+		{
+			name:     "publishes changes made while disconnected",
+			listen:   true,
+			attempts: 1,
+			resync:   true,
+			wantChanges: []string{
+				"added 33:33:33:33:33:33",
+				"removed 11:11:11:11:11:11",
+				"updated 22:22:22:22:22:22",
+			},
+		},
+		{name: "no server", attempts: 1, fail: true},
+		{name: "session stopped", listen: true, attempts: 1, closed: true, fail: true, wantErr: errorkinds.ErrSessionNotExist},
+		{name: "context cancelled", listen: true, attempts: 1, cancelled: true, fail: true, wantErr: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socketPath := filepath.Join(t.TempDir(), socketName)
+
+			if tt.listen {
+				listener, err := net.Listen("unix", socketPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer listener.Close()
+
+				go func() {
+					for {
+						conn, err := listener.Accept()
+						if err != nil {
+							return
+						}
+						defer conn.Close()
+
+						if tt.resync {
+							daemon := &testDaemon{conn: conn, handler: func(command testCommand) (any, error) {
+								if command.Name() == "rpc state-snapshot" {
+									return snapshot, nil
+								}
+
+								return nil, nil
+							}}
+
+							go daemon.serve()
+						}
+					}
+				}()
+			}
+
+			s := &HaraltdSession{}
+
+			ctx := s.reset(false)
+			defer s.Stop()
+
+			s.maxEventSize = config.DefaultMaxEventSize
+			s.store.AddAdapter(snapshot.Adapters[0])
+			for _, device := range known {
+				s.store.AddDevice(device)
+			}
+
+			sub, ok := bluetooth.DeviceEvents().Subscribe()
+			if !ok {
+				t.Fatal("cannot subscribe to device events")
+			}
+			defer sub.Unsubscribe()
+
+			// The events are buffered until the session is resynchronized, since
+			// events which are not received immediately are otherwise dropped.
+			sub.Pause(bluetooth.PauseMode{BufferSize: len(known) + len(snapshot.Devices)})
+
+			old, peer := net.Pipe()
+			defer peer.Close()
+
+			s.conn = old
+			s.socketPath = socketPath
+			s.reconnectAttempts = tt.attempts
+
+			if tt.closed {
+				s.sessionClosed.Store(true)
+			}
+
+			if tt.cancelled {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithCancel(ctx)
+				cancel()
+			}
+
+			err := s.reconnect(ctx, io.EOF)
+			if tt.fail {
+				if err == nil {
+					t.Fatal("reconnect() succeeded, want an error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("reconnect() = %v, want %v", err, tt.wantErr)
+				}
+				if s.connection() != old {
+					t.Error("connection was replaced after a failed reconnection")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("reconnect() = %v, want nil", err)
+			}
+			if s.connection() == old {
+				t.Error("connection was not replaced after reconnecting")
+			}
+
+			if !tt.resync {
+				return
+			}
+
+			// The listener receives the response to the state snapshot request,
+			// which is sent over the new connection.
+			go s.listen(ctx)
+
+			time.Sleep(100 * time.Millisecond)
+			sub.Resume()
+
+			var changes []string
+			for len(changes) < len(tt.wantChanges) {
+				select {
+				case device := <-sub.AddedEvents:
+					changes = append(changes, "added "+device.Address.String())
+
+				case device := <-sub.UpdatedEvents:
+					changes = append(changes, "updated "+device.Address.String())
+
+				case device := <-sub.RemovedEvents:
+					changes = append(changes, "removed "+device.Address.String())
+
+				case <-time.After(time.Second):
+					t.Fatalf("received device events %v, want %v", changes, tt.wantChanges)
+				}
+			}
+
+			slices.Sort(changes)
+			if !slices.Equal(changes, tt.wantChanges) {
+				t.Errorf("device events = %v, want %v", changes, tt.wantChanges)
+			}
+
+			devices, err := s.store.AdapterDevices(adapterAddress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(devices) != len(snapshot.Devices) {
+				t.Errorf("store has %d devices after resynchronizing, want %d", len(devices), len(snapshot.Devices))
+			}
+		})
+	}
+}
+
+func TestListenStopsWithoutReconnectAttempts(t *testing.T) {
+	s := &HaraltdSession{}
+
+	ctx := s.reset(false)
+	defer s.Stop()
+
+	conn, peer := net.Pipe()
+
+	s.conn = conn
+	s.maxEventSize = config.DefaultMaxEventSize
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.listen(ctx)
+	}()
+
+	peer.Close()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("listener did not stop after the connection was lost")
+	}
+
+	if !s.sessionClosed.Load() {
+		t.Error("session was not stopped after the connection was lost")
+	}
+}
+
+func TestExperimentalFeatures(t *testing.T) {
+	features, err := (&HaraltdSession{}).ExperimentalFeatures()
+	if err != nil || features == nil || len(features) != 0 {
+		t.Errorf("ExperimentalFeatures() = (%#v, %v), want an empty list", features, err)
+	}
+}
+
+func TestScanEvents(t *testing.T) {
+	const maxEventSize = 16
+
+	tests := []struct {
+		name       string
+		data       string
+		want       []string
+		wantErrors int
+	}{
+		{name: "below maximum size", data: "short\n", want: []string{"short"}},
+		{name: "at maximum size", data: strings.Repeat("a", maxEventSize) + "\n", want: []string{strings.Repeat("a", maxEventSize)}},
+		{name: "above maximum size", data: strings.Repeat("a", maxEventSize+1) + "\n", wantErrors: 1},
+		{
+			name:       "events after a discarded event",
+			data:       "first\n" + strings.Repeat("a", 4*maxEventSize) + "\nsecond\n",
+			want:       []string{"first", "second"},
+			wantErrors: 1,
+		},
+		{
+			name:       "consecutive discarded events",
+			data:       strings.Repeat("a", maxEventSize+1) + "\n" + strings.Repeat("b", maxEventSize+1) + "\nlast\n",
+			want:       []string{"last"},
+			wantErrors: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &HaraltdSession{}
+			s.reset(false)
+			defer s.Stop()
+
+			s.maxEventSize = maxEventSize
+
+			// The scanner is set up in the same way as the listener.
+			scanner := bufio.NewScanner(strings.NewReader(tt.data))
+			scanner.Buffer(make([]byte, 0, s.maxEventSize+1), s.maxEventSize+1)
+			scanner.Split(s.scanEvents())
+
+			var got []string
+			for scanner.Scan() {
+				got = append(got, scanner.Text())
+			}
+
+			if err := scanner.Err(); err != nil {
+				t.Fatalf("scanner error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("scanned events = %q, want %q", got, tt.want)
+			}
+
+			published := s.store.RecentErrors(0)
+			if len(published) != tt.wantErrors {
+				t.Fatalf("%d errors were published, want %d", len(published), tt.wantErrors)
+			}
+			for _, err := range published {
+				if err.Context["error_at"] != "listener-scan" {
+					t.Errorf("published error context = %v, want the listener scan error", err.Context)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchRawEvents(t *testing.T) {
+	address, err := bluetooth.ParseMAC("11:11:11:11:11:11")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adapterAddress, err := bluetooth.ParseMAC("00:00:00:00:00:0A")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deviceEvent, err := serde.MarshalJSON(map[string]any{
+		"event_id":     bluetooth.EventDevice,
+		"event_action": bluetooth.EventActionAdded,
+		"event": map[string]any{"device_event": bluetooth.DeviceData{
+			DeviceEventData: bluetooth.DeviceEventData{DeviceAddress: bluetooth.NewDeviceAddress(address, adapterAddress)},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		frame      string
+		wantRaw    bool
+		wantID     bluetooth.EventID
+		wantAction bluetooth.EventAction
+		wantErr    bool
+		wantTyped  bool
+	}{
+		{name: "event", frame: string(deviceEvent), wantRaw: true, wantID: bluetooth.EventDevice, wantAction: bluetooth.EventActionAdded, wantTyped: true},
+		{name: "undecodable frame", frame: "{not json", wantRaw: true, wantErr: true},
+		{name: "command response", frame: `{"request_id":1000,"status":"ok"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, daemon := newTestSession(t, 0, func(testCommand) (any, error) { return nil, nil })
+
+			raw, unsubscribe, err := s.WatchRawEvents(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer unsubscribe()
+
+			sub, ok := bluetooth.DeviceEvents().Subscribe()
+			if !ok {
+				t.Fatal("cannot subscribe to device events")
+			}
+			defer sub.Unsubscribe()
+
+			daemon.mu.Lock()
+			_, err = daemon.conn.Write([]byte(tt.frame + "\n"))
+			daemon.mu.Unlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case event := <-raw:
+				if !tt.wantRaw {
+					t.Fatalf("received raw event %+v for a frame which is not an event", event)
+				}
+				if string(event.Frame) != tt.frame {
+					t.Errorf("raw frame = %q, want %q", event.Frame, tt.frame)
+				}
+				if event.ID != tt.wantID || event.Action != tt.wantAction {
+					t.Errorf("raw event = (%v, %q), want (%v, %q)", event.ID, event.Action, tt.wantID, tt.wantAction)
+				}
+				if (event.Err != nil) != tt.wantErr {
+					t.Errorf("raw event error = %v, want error %v", event.Err, tt.wantErr)
+				}
+
+			case <-time.After(200 * time.Millisecond):
+				if tt.wantRaw {
+					t.Fatal("raw event was not received")
+				}
+			}
+
+			// The typed event is published in addition to the raw event.
+			select {
+			case device := <-sub.AddedEvents:
+				if !tt.wantTyped {
+					t.Fatalf("received device event %+v, want none", device)
+				}
+				if device.Address != address {
+					t.Errorf("device event address = %s, want %s", device.Address, address)
+				}
+
+			case <-time.After(200 * time.Millisecond):
+				if tt.wantTyped {
+					t.Fatal("device event was not received")
+				}
+			}
+		})
+	}
+}
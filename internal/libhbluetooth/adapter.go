@@ -4,6 +4,7 @@ package libhbluetooth
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Southclaws/fault"
 	"github.com/Southclaws/fault/fctx"
@@ -11,6 +12,7 @@ import (
 	"github.com/Southclaws/fault/ftag"
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+	"github.com/bluetuith-org/bluetooth-classic/api/optional"
 	"github.com/bluetuith-org/bluetooth-classic/internal/libhbluetooth/internal/lib"
 )
 
@@ -42,11 +44,24 @@ func (a *adapter) StopDiscovery() error {
 
 // SetPoweredState sets the powered state of the adapter.
 func (a *adapter) SetPoweredState(enable bool) error {
-	if _, err := a.check(); err != nil {
+	adapter, err := a.check()
+	if err != nil {
+		return err
+	}
+
+	if powered, ok := adapter.Powered.Get(); !ok || powered != enable {
+		a.publishPowerState(bluetooth.PowerStateTransition(enable))
+	}
+
+	if err := lib.SetAdapterPoweredState(a.key, enable); err != nil {
+		a.publishPowerState(bluetooth.PowerStateFromPowered(adapter.Powered))
+
 		return err
 	}
 
-	return lib.SetAdapterPoweredState(a.key, enable)
+	a.publishPowerState(bluetooth.PowerStateFromPowered(optional.New(enable)))
+
+	return nil
 }
 
 // SetDiscoverableState sets the discoverable state of the adapter.
@@ -67,6 +82,63 @@ func (a *adapter) SetPairableState(enable bool) error {
 	return lib.SetAdapterPairableState(a.key, enable)
 }
 
+// RemoveAllDevices removes (unpairs and forgets) all the devices associated with the adapter.
+// If a device cannot be removed, the remaining devices are still removed, and all the
+// errors that occurred are returned together. A removal event is published for each device
+// which is removed from the store.
+func (a *adapter) RemoveAllDevices() error {
+	devices, err := a.Devices()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, device := range devices {
+		if err := lib.DeviceRemove(device.DeviceAddress); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		a.removeDevice(device.DeviceEventData)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fault.Wrap(
+			err,
+			fctx.With(
+				context.Background(),
+				"error_at", "adapter-remove-all-devices",
+				"address", a.key.Address.String(),
+			),
+			ftag.With(ftag.Internal),
+			fmsg.With("Some devices could not be removed"),
+		)
+	}
+
+	return nil
+}
+
+// removeDevice removes the device from the store, and publishes its removal if the device
+// was present in the store.
+func (a *adapter) removeDevice(device bluetooth.DeviceEventData) {
+	if a.s.store.RemoveDevice(device.DeviceAddress) {
+		bluetooth.DeviceEvents().PublishRemoved(device)
+	}
+}
+
+// SetProperty sets a raw property of the adapter.
+// Currently is valid only on Linux.
+func (a *adapter) SetProperty(_ string, _ any) error {
+	return errorkinds.ErrNotSupported
+}
+
+// GetProperty gets a raw property of the adapter.
+// Currently is valid only on Linux.
+func (a *adapter) GetProperty(_ string) (any, error) {
+	return nil, errorkinds.ErrNotSupported
+}
+
 // Properties returns all the properties of the adapter.
 func (a *adapter) Properties() (bluetooth.AdapterData, error) {
 	return a.check()
@@ -81,6 +153,16 @@ func (a *adapter) Devices() ([]bluetooth.DeviceData, error) {
 	return a.s.store.AdapterDevices(a.key)
 }
 
+// publishPowerState updates the power state of the adapter in the store, and publishes the update.
+func (a *adapter) publishPowerState(state bluetooth.AdapterPowerState) {
+	updated, err := a.s.store.SetAdapterPowerState(a.key, state)
+	if err != nil {
+		return
+	}
+
+	bluetooth.AdapterEvents().PublishUpdated(updated)
+}
+
 // check validates whether the adapter properties are present within the global session store.
 func (a *adapter) check() (bluetooth.AdapterData, error) {
 	if a.s == nil || a.s.sessionClosed.Load() {
@@ -10,6 +10,7 @@ import (
 	"github.com/Southclaws/fault/fmsg"
 	"github.com/Southclaws/fault/ftag"
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/config"
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 	"github.com/bluetuith-org/bluetooth-classic/internal/libhbluetooth/internal/lib"
 	"github.com/google/uuid"
@@ -22,11 +23,7 @@ type device struct {
 
 // Pair will attempt to pair a bluetooth device that is in pairing mode.
 func (d *device) Pair() error {
-	if _, err := d.check(); err != nil {
-		return err
-	}
-
-	return lib.DevicePair(d.key)
+	return d.pair(context.Background())
 }
 
 // CancelPairing will cancel a pairing attempt.
@@ -41,11 +38,27 @@ func (d *device) CancelPairing() error {
 // Connect will attempt to connect an already paired bluetooth device
 // to an adapter.
 func (d *device) Connect() error {
+	return d.connect(context.Background())
+}
+
+// PairAndConnect will attempt to pair a bluetooth device, wait for its services
+// to be resolved, and then connect the device to the adapter.
+func (d *device) PairAndConnect(ctx context.Context) error {
 	if _, err := d.check(); err != nil {
 		return err
 	}
 
-	return lib.DeviceConnect(d.key)
+	ctx, cancel := config.WithOperationTimeout(ctx, d.s.operationTimeout)
+	defer cancel()
+
+	return d.s.store.PairAndConnect(ctx, d.key, d.pair, d.connect)
+}
+
+// PairWithCapability will attempt to pair a bluetooth device, using the provided
+// authentication capability for this pairing attempt only.
+// Currently is not supported.
+func (d *device) PairWithCapability(_ context.Context, _ bluetooth.AgentCapability) error {
+	return errorkinds.ErrNotSupported
 }
 
 // Disconnect will disconnect the bluetooth device from the adapter.
@@ -90,11 +103,61 @@ func (d *device) SetBlocked(_ bool) error {
 	return errorkinds.ErrNotSupported
 }
 
+// SetProperty sets a raw property of the device.
+// Currently is valid only on Linux.
+func (d *device) SetProperty(_ string, _ any) error {
+	return errorkinds.ErrNotSupported
+}
+
+// GetProperty gets a raw property of the device.
+// Currently is valid only on Linux.
+func (d *device) GetProperty(_ string) (any, error) {
+	return nil, errorkinds.ErrNotSupported
+}
+
+// ConnectionParameters returns the negotiated connection parameters of the device.
+// Currently is not supported.
+func (d *device) ConnectionParameters() (bluetooth.ConnParams, error) {
+	return bluetooth.ConnParams{}, errorkinds.ErrNotSupported
+}
+
 // Properties returns all the properties of the device.
 func (d *device) Properties() (bluetooth.DeviceData, error) {
 	return d.check()
 }
 
+// pair attempts to pair with the device until the context (ctx) is done.
+// If the context does not have a deadline, the session's operation timeout is applied.
+func (d *device) pair(ctx context.Context) error {
+	if _, err := d.check(); err != nil {
+		return err
+	}
+
+	ctx, cancel := config.WithOperationTimeout(ctx, d.s.operationTimeout)
+	defer cancel()
+
+	err := d.s.callWithTimeout(ctx, func() error {
+		return lib.DevicePair(d.key)
+	}, nil)
+	if err != nil && ctx.Err() != nil {
+		_ = lib.DevicePairCancel(d.key)
+	}
+
+	return err
+}
+
+// connect attempts to connect to the device until the context (ctx) is done.
+// If the context does not have a deadline, the session's operation timeout is applied.
+func (d *device) connect(ctx context.Context) error {
+	if _, err := d.check(); err != nil {
+		return err
+	}
+
+	return d.s.callWithTimeout(ctx, func() error {
+		return lib.DeviceConnect(d.key)
+	}, nil)
+}
+
 func (d *device) check() (bluetooth.DeviceData, error) {
 	if d.s == nil || d.s.sessionClosed.Load() {
 		return bluetooth.DeviceData{}, fault.Wrap(
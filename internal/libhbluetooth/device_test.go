@@ -0,0 +1,18 @@
+//go:build !linux && libhbluetooth
+
+package libhbluetooth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
+	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
+)
+
+func TestConnectionParametersNotSupported(t *testing.T) {
+	params, err := (&device{}).ConnectionParameters()
+	if !errors.Is(err, errorkinds.ErrNotSupported) || params != (bluetooth.ConnParams{}) {
+		t.Errorf("ConnectionParameters() = (%+v, %v), want %v", params, err, errorkinds.ErrNotSupported)
+	}
+}
@@ -58,6 +58,10 @@ func (a *adapterNative) toAdapterData() bluetooth.AdapterData {
 	checkAndSetAttrs(propIsPairable, a.Attributes, optSetFunc(&adapter.Pairable, a.IsPairable))
 	checkAndSetAttrs(propIsDiscovering, a.Attributes, optSetFunc(&adapter.Discovering, a.IsDiscovering))
 
+	if _, ok := adapter.Powered.Get(); ok {
+		adapter.PowerState = bluetooth.PowerStateFromPowered(adapter.Powered)
+	}
+
 	name := bytePtrToString(a.Name)
 	if name != "" {
 		adapter.Name = optional.New(name)
@@ -74,6 +74,33 @@ type pairingRequestData struct {
 	Pincode  [16]byte
 }
 
+// auditRecord returns an audit record for the pairing request.
+func (p pairingRequestData) auditRecord() bluetooth.AuthAuditRecord {
+	record := bluetooth.AuthAuditRecord{DeviceAddress: p.DeviceID.ToDeviceAddress()}
+
+	switch p.Type {
+	case authTypeDisplayPinCode:
+		record.Request = bluetooth.AuthRequestDisplayPinCode
+		record.Pincode = string(p.Pincode[:])
+
+	case authTypeDisplayPassKey:
+		record.Request = bluetooth.AuthRequestDisplayPasskey
+		record.Passkey = p.Passkey
+
+	case authTypeConfirmPasskey:
+		record.Request = bluetooth.AuthRequestConfirmPasskey
+		record.Passkey = p.Passkey
+
+	case authTypeAuthorizePairing:
+		record.Request = bluetooth.AuthRequestAuthorizePairing
+
+	case authTypeAuthorizeService:
+		record.Request = bluetooth.AuthRequestAuthorizeService
+	}
+
+	return record
+}
+
 type pairingResponseData struct {
 	Passkey uint32
 	Pincode [16]byte
@@ -136,6 +163,8 @@ func handleAuthEvent(etype authEventType, request unsafe.Pointer) {
 				return
 			}
 
+			bluetooth.AuditAuthorization(authorizer, ctx, data.auditRecord(), err)
+
 			var responseData pairingResponseData
 			if err != nil && errors.Is(err, context.Canceled) {
 				return
@@ -157,7 +186,14 @@ func handleAuthEvent(etype authEventType, request unsafe.Pointer) {
 		raiseAuthRequest(authID, func(ctx bluetooth.AuthTimeout) {
 			confirm := true
 
-			if err := authorizer.AuthorizeTransfer(ctx, data); err != nil {
+			err := authorizer.AuthorizeTransfer(ctx, data)
+			bluetooth.AuditAuthorization(authorizer, ctx, bluetooth.AuthAuditRecord{
+				DeviceAddress: data.DeviceAddress,
+				Request:       bluetooth.AuthRequestAuthorizeTransfer,
+				ObjectPush:    data,
+			}, err)
+
+			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					return
 				}
@@ -85,6 +85,10 @@ func (d *deviceNative) ToDeviceData() bluetooth.DeviceData {
 	checkAndSetAttrs(propHasRSSI, d.Attributes, optSetFunc(&device.RSSI, d.HasRSSI))
 	checkAndSetAttrs(propHasBatteryPercentage, d.Attributes, optSetFunc(&device.Percentage, d.HasBatteryPercentage))
 
+	// The library does not expose the advertising data flags of the device,
+	// so the device is assumed to have been discovered via an inquiry.
+	device.Connectable = bluetooth.ConnectableFromAdvertisingFlags(device.AdvertisingFlags)
+
 	return device
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
 	"github.com/bluetuith-org/bluetooth-classic/api/bluetooth"
 	bcfg "github.com/bluetuith-org/bluetooth-classic/api/config"
+	sstore "github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
 	ffi "github.com/bluetuith-org/libffi-go"
 )
 
@@ -30,6 +31,8 @@ type libHandle struct {
 	waitForExitCh chan struct{}
 
 	authorizer bluetooth.SessionAuthorizer
+	store      *sstore.SessionStore
+	timer      *bluetooth.ObjectPushTimer
 
 	mu sync.Mutex
 }
@@ -40,7 +43,7 @@ func newLibHandle() *libHandle {
 	}
 }
 
-func (l *libHandle) initLibrary(authorizer bluetooth.SessionAuthorizer, cfg bcfg.Configuration) error {
+func (l *libHandle) initLibrary(authorizer bluetooth.SessionAuthorizer, store *sstore.SessionStore, cfg bcfg.Configuration) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
@@ -75,6 +78,8 @@ func (l *libHandle) initLibrary(authorizer bluetooth.SessionAuthorizer, cfg bcfg
 	l.waitForExitCh = make(chan struct{}, 1)
 
 	l.authorizer = authorizer
+	l.store = store
+	l.timer = bluetooth.NewObjectPushTimer()
 
 	for _, funInits := range [][]funHandle{
 		getLibraryFunHandles(),
@@ -143,11 +148,12 @@ func (l *libHandle) closeLibrary(fn func()) error {
 	return l.removeEventHandlers()
 }
 
-// Initialize loads and initializes the library
+// Initialize loads and initializes the library. The store is used to record
+// the transfer statistics of devices.
 //
 //revive:disable
-func Initialize(authorizer bluetooth.SessionAuthorizer, cfg bcfg.Configuration) error {
-	if err := _libHandle.initLibrary(authorizer, cfg); err != nil {
+func Initialize(authorizer bluetooth.SessionAuthorizer, store *sstore.SessionStore, cfg bcfg.Configuration) error {
+	if err := _libHandle.initLibrary(authorizer, store, cfg); err != nil {
 		return err
 	}
 
@@ -203,7 +209,7 @@ func GetFeatures() appfeatures.Features {
 
 	_hbGetFeatures.Call(&f)
 
-	return f
+	return f.Known()
 }
 
 var (
@@ -171,17 +171,26 @@ func oppCallAdapterFunc(adapterAddress bluetooth.AdapterAddress, fn ffi.Fun) err
 	return libErr.getError()
 }
 
+// handleOppEvent records the transfer within the session store, so that the transfer
+// statistics of the associated device are updated, and publishes the transfer event.
 func handleOppEvent(action bluetooth.EventAction, data *oppTransferData) {
 	oppData := data.toObjectPushData()
+	store, timer := _libHandle.store, _libHandle.timer
 
 	switch action {
 	case bluetooth.EventActionAdded:
+		timer.Track(&oppData.ObjectPushEventData)
+		store.AddTransfer(oppData)
 		bluetooth.ObjectPushEvents().PublishAdded(oppData)
 
 	case bluetooth.EventActionUpdated:
+		timer.Track(&oppData.ObjectPushEventData)
+		store.UpdateTransfer(oppData.ObjectPushEventData)
 		bluetooth.ObjectPushEvents().PublishUpdated(oppData.ObjectPushEventData)
 
 	case bluetooth.EventActionRemoved:
+		timer.Remove(&oppData.ObjectPushEventData)
+		store.RemoveTransfer(oppData.ObjectPushEventData)
 		bluetooth.ObjectPushEvents().PublishRemoved(oppData.ObjectPushEventData)
 	}
 }
@@ -37,13 +37,18 @@ type obexObjectPush struct {
 // CreateSession creates a new Obex session with a device.
 // The context (ctx) can be provided in case this function call
 // needs to be cancelled, since this function call can take some time
-// to complete.
-func (o *obexObjectPush) CreateSession(_ context.Context) error {
+// to complete. If the context does not have a deadline, the session's operation
+// timeout is applied.
+func (o *obexObjectPush) CreateSession(ctx context.Context, _ ...bluetooth.ObexSessionOptions) error {
 	if err := o.check(); err != nil {
 		return err
 	}
 
-	return lib.OppCreateSession(o.key)
+	return o.s.callWithTimeout(ctx, func() error {
+		return lib.OppCreateSession(o.key)
+	}, func() {
+		_ = lib.OppRemoveSession(o.key)
+	})
 }
 
 // RemoveSession removes a created Obex session.
@@ -91,6 +96,27 @@ func (o *obexObjectPush) ResumeTransfer() error {
 	return lib.OppResumeTransfer(o.key)
 }
 
+// Transfers returns the IDs of all the transfers that are queued or active
+// within the session.
+func (o *obexObjectPush) Transfers() ([]bluetooth.ObjectPushTransferID, error) {
+	return nil, errorkinds.ErrNotSupported
+}
+
+// SuspendTransferWithID suspends the transfer with the provided transfer ID.
+func (o *obexObjectPush) SuspendTransferWithID(_ bluetooth.ObjectPushTransferID) error {
+	return errorkinds.ErrNotSupported
+}
+
+// ResumeTransferWithID resumes the transfer with the provided transfer ID.
+func (o *obexObjectPush) ResumeTransferWithID(_ bluetooth.ObjectPushTransferID) error {
+	return errorkinds.ErrNotSupported
+}
+
+// CanResume returns whether the transfer with the provided transfer ID can be resumed.
+func (o *obexObjectPush) CanResume(_ bluetooth.ObjectPushTransferID) (bool, error) {
+	return false, errorkinds.ErrNotSupported
+}
+
 func (o *obexObjectPush) check() error {
 	switch {
 	case !o.isEnabled || o.s == nil || o.s.sessionClosed.Load():
@@ -6,6 +6,7 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/Southclaws/fault"
 	"github.com/Southclaws/fault/fctx"
@@ -16,6 +17,7 @@ import (
 	"github.com/bluetuith-org/bluetooth-classic/api/errorkinds"
 	"github.com/bluetuith-org/bluetooth-classic/api/platforminfo"
 	"github.com/bluetuith-org/bluetooth-classic/internal/libhbluetooth/internal/lib"
+	"github.com/google/uuid"
 
 	ac "github.com/bluetuith-org/bluetooth-classic/api/appfeatures"
 	sstore "github.com/bluetuith-org/bluetooth-classic/api/helpers/sessionstore"
@@ -30,10 +32,13 @@ type BluetoothLibrary struct {
 
 	sessionClosed atomic.Bool
 	store         sstore.SessionStore
+	formatter     atomic.Pointer[bluetooth.AddressFormatter]
 
 	obexEnabled      bool
 	oppServerStarted bool
 
+	operationTimeout time.Duration
+
 	sync.Mutex
 }
 
@@ -43,6 +48,8 @@ type BluetoothLibrary struct {
 func (b *BluetoothLibrary) Start(authHandler bluetooth.SessionAuthorizer, cfg config.Configuration) (*ac.FeatureSet, platforminfo.PlatformInfo, error) {
 	var ce ac.Errors
 
+	cfg = cfg.WithDefaults()
+
 	platform := platforminfo.NewPlatformInfo("Generic", implementation)
 
 	var initialized bool
@@ -60,7 +67,11 @@ func (b *BluetoothLibrary) Start(authHandler bluetooth.SessionAuthorizer, cfg co
 	}
 
 	b.authorizer = authHandler
-	if err := lib.Initialize(authHandler, cfg); err != nil {
+
+	b.store = sstore.NewSessionStore()
+	b.store.SetErrorHistoryCapacity(cfg.ErrorHistoryCapacity)
+
+	if err := lib.Initialize(authHandler, &b.store, cfg); err != nil {
 		return nil, platform, fault.Wrap(
 			err,
 			fctx.With(context.Background(), "error_at", "init-lib"),
@@ -69,7 +80,6 @@ func (b *BluetoothLibrary) Start(authHandler bluetooth.SessionAuthorizer, cfg co
 		)
 	}
 
-	b.store = sstore.NewSessionStore()
 	if err := b.refreshStore(); err != nil {
 		return nil, platform, fault.Wrap(
 			err,
@@ -79,14 +89,23 @@ func (b *BluetoothLibrary) Start(authHandler bluetooth.SessionAuthorizer, cfg co
 		)
 	}
 
+	// The library does not report the version of the Bluetooth stack, or the address type
+	// and supported transports of the adapter, so only the adapter address is populated.
+	if adapters, err := b.store.Adapters(); err == nil {
+		platform.AdapterAddress = adapters[0].Address.String()
+	}
+
 	features := lib.GetFeatures()
 	for _, absentFeatures := range features.AbsentFeatures() {
 		ce.Append(ac.NewError(absentFeatures, errorkinds.ErrNotSupported))
 	}
 
 	b.obexEnabled = cfg.EnableObexServices
+	b.operationTimeout = cfg.OperationTimeout
 
 	b.features = ac.NewFeatureSet(features, ce)
+	b.features.SetMissing(ac.OptionalFeaturePairingCapability, ac.OptionalFeatureProfileConnection)
+
 	if b.features.Has(ac.FeatureSendFile, ac.FeatureReceiveFile) && cfg.EnableObexServices {
 		adapters, err := b.store.Adapters()
 		if err != nil {
@@ -133,6 +152,47 @@ func (b *BluetoothLibrary) Stop() error {
 	return nil
 }
 
+// ExperimentalFeatures returns the identifiers of the experimental features that are enabled
+// within the Bluetooth daemon. This is currently not exposed by libhbluetooth, so an empty list is returned.
+func (b *BluetoothLibrary) ExperimentalFeatures() ([]string, error) {
+	return []string{}, nil
+}
+
+// callWithTimeout calls the provided function and waits for it to complete, until the context (ctx)
+// is done. If the context does not have a deadline, the session's operation timeout is applied.
+// Note that the underlying library call cannot be cancelled, and will complete in the background.
+// If a cleanup function is provided, it is called if the library call succeeds after the context is done.
+// If the context is already done, the function is not called.
+func (b *BluetoothLibrary) callWithTimeout(ctx context.Context, fn func() error, cleanup func()) error {
+	ctx, cancel := config.WithOperationTimeout(ctx, b.operationTimeout)
+	defer cancel()
+
+	if ctx.Err() != nil {
+		return errorkinds.ContextError(ctx)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+
+	case <-ctx.Done():
+		if cleanup != nil {
+			go func() {
+				if err := <-done; err == nil {
+					cleanup()
+				}
+			}()
+		}
+
+		return errorkinds.ContextError(ctx)
+	}
+}
+
 // Adapters returns a list of known adapters.
 func (b *BluetoothLibrary) Adapters() ([]bluetooth.AdapterData, error) {
 	return b.store.Adapters()
@@ -148,6 +208,42 @@ func (b *BluetoothLibrary) Device(address bluetooth.DeviceAddress) bluetooth.Dev
 	return &device{s: b, key: address}
 }
 
+// RefreshAdapter fetches the properties of the adapter from the library, updates the adapter
+// within the session store, and publishes an adapter update event.
+func (b *BluetoothLibrary) RefreshAdapter(address bluetooth.AdapterAddress) (bluetooth.AdapterData, error) {
+	if _, err := (&adapter{b, address}).check(); err != nil {
+		return bluetooth.AdapterData{}, err
+	}
+
+	adapter, err := lib.AdapterProperties(address)
+	if err != nil {
+		return bluetooth.AdapterData{}, err
+	}
+
+	b.store.AddAdapter(adapter)
+	bluetooth.AdapterEvents().PublishUpdated(adapter.AdapterEventData)
+
+	return adapter, nil
+}
+
+// RefreshDevice fetches the properties of the device from the library, updates the device
+// within the session store, and publishes a device update event.
+func (b *BluetoothLibrary) RefreshDevice(address bluetooth.DeviceAddress) (bluetooth.DeviceData, error) {
+	if _, err := (&device{b, address}).check(); err != nil {
+		return bluetooth.DeviceData{}, err
+	}
+
+	device, err := lib.DeviceProperties(address)
+	if err != nil {
+		return bluetooth.DeviceData{}, err
+	}
+
+	b.store.AddDevice(device)
+	bluetooth.DeviceEvents().PublishUpdated(device.DeviceEventData)
+
+	return device, nil
+}
+
 // Obex returns a function call interface to invoke obex related functions.
 func (b *BluetoothLibrary) Obex(address bluetooth.DeviceAddress) bluetooth.Obex {
 	return &obex{b, address, b.obexEnabled}
@@ -164,6 +260,74 @@ func (b *BluetoothLibrary) MediaPlayer(_ bluetooth.DeviceAddress) bluetooth.Medi
 	return &mediaPlayer{}
 }
 
+// WatchServicesResolved waits for the services of the device to be resolved, and sends
+// the device's service UUIDs once via the returned channel.
+func (b *BluetoothLibrary) WatchServicesResolved(ctx context.Context, address bluetooth.DeviceAddress) (<-chan []uuid.UUID, func()) {
+	return b.store.WatchServicesResolved(ctx, address)
+}
+
+// WatchConnectedDevices sends the list of connected devices via the returned channel,
+// each time a device connects or disconnects.
+func (b *BluetoothLibrary) WatchConnectedDevices(ctx context.Context) (<-chan []bluetooth.DeviceData, func()) {
+	return b.store.WatchConnectedDevices(ctx)
+}
+
+// WatchLinkQuality samples the link quality of the connected device at each interval.
+// Currently is not supported.
+func (b *BluetoothLibrary) WatchLinkQuality(_ context.Context, _ bluetooth.DeviceAddress, _ time.Duration) (<-chan bluetooth.LinkQuality, func(), error) {
+	return nil, func() {}, errorkinds.ErrNotSupported
+}
+
+// SetLowPowerMode enables or disables the low-power mode, which suspends the link quality sampling.
+func (b *BluetoothLibrary) SetLowPowerMode(enable bool) {
+	b.store.SetLowPowerMode(enable)
+}
+
+// LowPowerMode returns whether the low-power mode is enabled.
+func (b *BluetoothLibrary) LowPowerMode() bool {
+	return b.store.LowPowerMode()
+}
+
+// WatchRawEvents sends the raw event frames that are received from the server via the returned channel.
+// Currently is not supported.
+func (b *BluetoothLibrary) WatchRawEvents(_ context.Context) (<-chan bluetooth.RawEvent, func(), error) {
+	return nil, func() {}, errorkinds.ErrNotSupported
+}
+
+// RecentErrors returns the 'n' most recently published errors of the session.
+func (b *BluetoothLibrary) RecentErrors(n int) []bluetooth.BluetoothError {
+	return b.store.RecentErrors(n)
+}
+
+// TransferStats returns the aggregate statistics of the file transfers with the device.
+func (b *BluetoothLibrary) TransferStats(address bluetooth.DeviceAddress) (bluetooth.TransferStats, error) {
+	return b.store.TransferStats(address)
+}
+
+// ResetTransferStats resets the file transfer statistics of the device.
+func (b *BluetoothLibrary) ResetTransferStats(address bluetooth.DeviceAddress) error {
+	return b.store.ResetTransferStats(address)
+}
+
+// SupportsObexTarget returns whether the device advertises the service of the Obex target.
+func (b *BluetoothLibrary) SupportsObexTarget(address bluetooth.MacAddress, target bluetooth.ObexTarget) (bool, error) {
+	return b.store.SupportsObexTarget(address, target)
+}
+
+// AddressFormatter returns the formatter that is used to display Bluetooth addresses.
+func (b *BluetoothLibrary) AddressFormatter() bluetooth.AddressFormatter {
+	if formatter := b.formatter.Load(); formatter != nil {
+		return *formatter
+	}
+
+	return bluetooth.AddressFormatter{}
+}
+
+// SetAddressFormatter sets the formatter that is used to display Bluetooth addresses.
+func (b *BluetoothLibrary) SetAddressFormatter(formatter bluetooth.AddressFormatter) {
+	b.formatter.Store(&formatter)
+}
+
 func (b *BluetoothLibrary) refreshStore() error {
 	adapters, err := lib.GetAdapters()
 	if err != nil {
@@ -0,0 +1,12 @@
+//go:build !linux && libhbluetooth
+
+package libhbluetooth
+
+import "testing"
+
+func TestExperimentalFeatures(t *testing.T) {
+	features, err := (&BluetoothLibrary{}).ExperimentalFeatures()
+	if err != nil || features == nil || len(features) != 0 {
+		t.Errorf("ExperimentalFeatures() = (%#v, %v), want an empty list", features, err)
+	}
+}